@@ -3,28 +3,87 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
 )
 
+// decodeFunc parses config data into the value pointed to by config
+type decodeFunc func(data []byte, config any) error
+
 func Load[T any](path string) (T, error) {
 	var config T
 
-	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".json":
-		return LoadJSON[T](path)
-	case ".yaml", ".yml":
-		return LoadYAML[T](path)
-	default:
-		return config, fmt.Errorf("unsupported file format: %s (supported: .json, .yaml, .yml)", ext)
+	decode, err := decoderFor(path)
+	if err != nil {
+		return config, err
 	}
+
+	return load[T](path, decode)
 }
 
 func LoadJSON[T any](path string) (T, error) {
+	return load[T](path, decodeJSON)
+}
+
+// LoadJSONReader loads a JSON config from r, e.g. stdin or a file opened
+// from an embed.FS
+func LoadJSONReader[T any](r io.Reader) (T, error) {
+	return loadReader[T](r, decodeJSON)
+}
+
+// LoadJSONBytes loads a JSON config from data
+func LoadJSONBytes[T any](data []byte) (T, error) {
+	return loadBytes[T](data, decodeJSON)
+}
+
+func LoadYAML[T any](path string) (T, error) {
+	return load[T](path, decodeYAML)
+}
+
+// LoadYAMLReader loads a YAML config from r
+func LoadYAMLReader[T any](r io.Reader) (T, error) {
+	return loadReader[T](r, decodeYAML)
+}
+
+// LoadYAMLBytes loads a YAML config from data
+func LoadYAMLBytes[T any](data []byte) (T, error) {
+	return loadBytes[T](data, decodeYAML)
+}
+
+func LoadTOML[T any](path string) (T, error) {
+	return load[T](path, decodeTOML)
+}
+
+// LoadMerged loads each file in paths in order and deep-merges them into one
+// config, so later files override earlier ones: nested structs are merged
+// field by field, maps key by key, and slices are replaced. Each file's
+// format is detected from its extension as in Load.
+func LoadMerged[T any](paths ...string) (T, error) {
+	var config T
+	if err := setDefaults(&config); err != nil {
+		return config, err
+	}
+
+	for _, path := range paths {
+		decode, err := decoderFor(path)
+		if err != nil {
+			return config, err
+		}
+		if err := loadInto(path, &config, decode); err != nil {
+			return config, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	return config, nil
+}
+
+// load reads the file at path and decodes it over the defaults
+func load[T any](path string, decode decodeFunc) (T, error) {
 	var config T
 
 	data, err := os.ReadFile(path)
@@ -32,24 +91,80 @@ func LoadJSON[T any](path string) (T, error) {
 		return config, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	if err := json.Unmarshal(data, &config); err != nil {
-		return config, fmt.Errorf("failed to parse JSON config: %w", err)
+	return loadBytes[T](data, decode)
+}
+
+// loadReader reads all of r and decodes it over the defaults
+func loadReader[T any](r io.Reader, decode decodeFunc) (T, error) {
+	var config T
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return config, fmt.Errorf("failed to read config: %w", err)
 	}
 
-	return config, nil
+	return loadBytes[T](data, decode)
 }
 
-func LoadYAML[T any](path string) (T, error) {
+// loadBytes applies defaults, then decodes data over them
+func loadBytes[T any](data []byte, decode decodeFunc) (T, error) {
 	var config T
 
+	if err := setDefaults(&config); err != nil {
+		return config, err
+	}
+
+	if err := decode(data, &config); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// loadInto reads the file at path and decodes it on top of config
+func loadInto(path string, config any, decode decodeFunc) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return config, fmt.Errorf("failed to read config file: %w", err)
+		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return config, fmt.Errorf("failed to parse YAML config: %w", err)
+	return decode(data, config)
+}
+
+// decoderFor picks a decoder from the extension of path
+func decoderFor(path string) (decodeFunc, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".json":
+		return decodeJSON, nil
+	case ".yaml", ".yml":
+		return decodeYAML, nil
+	case ".toml":
+		return decodeTOML, nil
+	case ".env":
+		return decodeDotEnv, nil
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s (supported: .json, .yaml, .yml, .toml, .env)", ext)
 	}
+}
 
-	return config, nil
+func decodeJSON(data []byte, config any) error {
+	if err := json.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("failed to parse JSON config: %w", err)
+	}
+	return nil
+}
+
+func decodeYAML(data []byte, config any) error {
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("failed to parse YAML config: %w", err)
+	}
+	return nil
+}
+
+func decodeTOML(data []byte, config any) error {
+	if err := toml.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("failed to parse TOML config: %w", err)
+	}
+	return nil
 }
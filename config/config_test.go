@@ -7,19 +7,19 @@ import (
 )
 
 type TestConfig struct {
-	AppName   string            `json:"app_name" yaml:"app_name"`
-	Port      int               `json:"port" yaml:"port"`
-	Debug     bool              `json:"debug" yaml:"debug"`
-	Database  DatabaseConfig    `json:"database" yaml:"database"`
-	Endpoints []string          `json:"endpoints" yaml:"endpoints"`
-	Metadata  map[string]string `json:"metadata" yaml:"metadata"`
+	AppName   string            `json:"app_name" yaml:"app_name" toml:"app_name"`
+	Port      int               `json:"port" yaml:"port" toml:"port"`
+	Debug     bool              `json:"debug" yaml:"debug" toml:"debug"`
+	Database  DatabaseConfig    `json:"database" yaml:"database" toml:"database"`
+	Endpoints []string          `json:"endpoints" yaml:"endpoints" toml:"endpoints"`
+	Metadata  map[string]string `json:"metadata" yaml:"metadata" toml:"metadata"`
 }
 
 type DatabaseConfig struct {
-	Host     string `json:"host" yaml:"host"`
-	Port     int    `json:"port" yaml:"port"`
-	Username string `json:"username" yaml:"username"`
-	Password string `json:"password" yaml:"password"`
+	Host     string `json:"host" yaml:"host" toml:"host"`
+	Port     int    `json:"port" yaml:"port" toml:"port"`
+	Username string `json:"username" yaml:"username" toml:"username"`
+	Password string `json:"password" yaml:"password" toml:"password"`
 }
 
 func TestLoadJSON(t *testing.T) {
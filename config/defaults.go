@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// setDefaults sets the fields of config from their `default` struct tags,
+// recursing into nested structs. It runs before decoding, so values in the
+// file, including false, 0 and "", override the defaults. Supported field
+// types are those of LoadWithEnv.
+func setDefaults[T any](config *T) error {
+	v := reflect.ValueOf(config).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	return applyDefaults(v)
+}
+
+func applyDefaults(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			if err := applyDefaults(fv); err != nil {
+				return err
+			}
+			continue
+		case field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct:
+			// Still nil before decoding, so there is nothing to fill in
+			continue
+		}
+
+		value, ok := field.Tag.Lookup("default")
+		if !ok {
+			continue
+		}
+		if err := setValue(fv, value); err != nil {
+			return fmt.Errorf("invalid default for %s.%s: %w", t.Name(), field.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type DefaultsConfig struct {
+	AppName  string                 `json:"app_name" yaml:"app_name" default:"my-app"`
+	Port     int                    `json:"port" yaml:"port" default:"8080"`
+	Debug    bool                   `json:"debug" yaml:"debug" default:"true"`
+	Timeout  time.Duration          `json:"timeout" yaml:"timeout" default:"30s"`
+	Database DefaultsDatabaseConfig `json:"database" yaml:"database"`
+}
+
+type DefaultsDatabaseConfig struct {
+	Host string `json:"host" yaml:"host" default:"localhost"`
+	Port int    `json:"port" yaml:"port" default:"5432"`
+}
+
+func TestLoadJSONDefaults(t *testing.T) {
+	jsonContent := `{
+		"app_name": "test-app",
+		"database": {
+			"port": 6543
+		}
+	}`
+
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(jsonFile, []byte(jsonContent), 0644); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+
+	config, err := LoadJSON[DefaultsConfig](jsonFile)
+	if err != nil {
+		t.Fatalf("LoadJSON failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+	if config.Port != 8080 {
+		t.Errorf("Expected default Port 8080, got %d", config.Port)
+	}
+	if !config.Debug {
+		t.Error("Expected default Debug to be true")
+	}
+	if config.Timeout != 30*time.Second {
+		t.Errorf("Expected default Timeout 30s, got %v", config.Timeout)
+	}
+	if config.Database.Host != "localhost" {
+		t.Errorf("Expected default Database.Host 'localhost', got '%s'", config.Database.Host)
+	}
+	if config.Database.Port != 6543 {
+		t.Errorf("Expected Database.Port 6543, got %d", config.Database.Port)
+	}
+}
+
+func TestLoadYAMLDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	yamlFile := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(yamlFile, []byte("app_name: test-app\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test YAML file: %v", err)
+	}
+
+	config, err := LoadYAML[DefaultsConfig](yamlFile)
+	if err != nil {
+		t.Fatalf("LoadYAML failed: %v", err)
+	}
+
+	if config.Port != 8080 {
+		t.Errorf("Expected default Port 8080, got %d", config.Port)
+	}
+}
+
+func TestLoadInvalidDefault(t *testing.T) {
+	type BadConfig struct {
+		Port int `json:"port" default:"eighty"`
+	}
+
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(jsonFile, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+
+	if _, err := LoadJSON[BadConfig](jsonFile); err == nil {
+		t.Fatal("Expected error for invalid default, got nil")
+	}
+}
+
+func TestLoadExplicitZeroOverridesDefault(t *testing.T) {
+	jsonContent := `{
+		"app_name": "",
+		"port": 0,
+		"debug": false
+	}`
+
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(jsonFile, []byte(jsonContent), 0644); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+
+	config, err := LoadJSON[DefaultsConfig](jsonFile)
+	if err != nil {
+		t.Fatalf("LoadJSON failed: %v", err)
+	}
+
+	if config.Debug {
+		t.Error("Expected explicit false to override default Debug true")
+	}
+	if config.Port != 0 {
+		t.Errorf("Expected explicit Port 0, got %d", config.Port)
+	}
+	if config.AppName != "" {
+		t.Errorf("Expected explicit empty AppName, got '%s'", config.AppName)
+	}
+	if config.Timeout != 30*time.Second {
+		t.Errorf("Expected default Timeout 30s, got %v", config.Timeout)
+	}
+}
+
+func TestLoadYAMLExplicitFalseOverridesDefault(t *testing.T) {
+	tmpDir := t.TempDir()
+	yamlFile := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(yamlFile, []byte("debug: false\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test YAML file: %v", err)
+	}
+
+	config, err := LoadYAML[DefaultsConfig](yamlFile)
+	if err != nil {
+		t.Fatalf("LoadYAML failed: %v", err)
+	}
+
+	if config.Debug {
+		t.Error("Expected explicit false to override default Debug true")
+	}
+	if config.Port != 8080 {
+		t.Errorf("Expected default Port 8080, got %d", config.Port)
+	}
+}
+
+func TestLoadMergedExplicitFalseOverridesDefault(t *testing.T) {
+	tmpDir := t.TempDir()
+	base := filepath.Join(tmpDir, "base.yaml")
+	override := filepath.Join(tmpDir, "override.json")
+	if err := os.WriteFile(base, []byte("port: 9090\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test YAML file: %v", err)
+	}
+	if err := os.WriteFile(override, []byte(`{"debug": false}`), 0644); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+
+	config, err := LoadMerged[DefaultsConfig](base, override)
+	if err != nil {
+		t.Fatalf("LoadMerged failed: %v", err)
+	}
+
+	if config.Debug {
+		t.Error("Expected explicit false to override default Debug true")
+	}
+	if config.Port != 9090 {
+		t.Errorf("Expected Port 9090, got %d", config.Port)
+	}
+	if config.Database.Host != "localhost" {
+		t.Errorf("Expected default Database.Host 'localhost', got '%s'", config.Database.Host)
+	}
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// LoadDotEnv loads a .env file of KEY=value lines into T. Keys are matched
+// to fields like LoadWithEnv without a prefix, so DATABASE_HOST sets the
+// field tagged "database" → "host". Lines may start with "export", "#"
+// starts a comment, and values may be single-quoted (literal) or
+// double-quoted (with \n, \t, \" and \\ escapes, possibly spanning lines).
+func LoadDotEnv[T any](path string) (T, error) {
+	return load[T](path, decodeDotEnv)
+}
+
+// decodeDotEnv parses .env data and sets the matching fields of the struct
+// pointed to by config, leaving other fields untouched
+func decodeDotEnv(data []byte, config any) error {
+	vars, err := parseDotEnv(string(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse .env config: %w", err)
+	}
+
+	v := reflect.ValueOf(config).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	lookup := func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	}
+	if err := applyVars(v, "", lookup); err != nil {
+		return fmt.Errorf("failed to parse .env config: %w", err)
+	}
+	return nil
+}
+
+// parseDotEnv parses .env content into a map keyed by upper-cased names
+func parseDotEnv(data string) (map[string]string, error) {
+	vars := make(map[string]string)
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+
+	line := 0
+	for len(data) > 0 {
+		line++
+		var current string
+		current, data, _ = strings.Cut(data, "\n")
+
+		current = strings.TrimSpace(current)
+		if current == "" || strings.HasPrefix(current, "#") {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(current, "export "); ok {
+			current = strings.TrimLeft(rest, " \t")
+		}
+
+		key, value, ok := strings.Cut(current, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=value", line)
+		}
+		value = strings.TrimLeft(value, " \t")
+
+		start := line
+		if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+			// A quoted value may continue on the following lines
+			quoted, rest, lines, err := parseQuoted(value, data)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", start, err)
+			}
+			line += lines
+			data = rest
+			value = quoted
+		} else {
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = value[:i]
+			}
+			value = strings.TrimSpace(value)
+		}
+
+		vars[strings.ToUpper(key)] = value
+	}
+	return vars, nil
+}
+
+// parseQuoted parses a quoted value starting at value and, if the closing
+// quote is not on the same line, continuing into rest. It returns the
+// unquoted value, the input left after the value's line and the number of
+// extra lines consumed.
+func parseQuoted(value, rest string) (string, string, int, error) {
+	quote := value[0]
+	input := value[1:]
+	lines := 0
+
+	var b strings.Builder
+	for {
+		for i := 0; i < len(input); i++ {
+			c := input[i]
+			switch {
+			case c == quote:
+				if trailing := strings.TrimSpace(input[i+1:]); trailing != "" && !strings.HasPrefix(trailing, "#") {
+					return "", "", 0, fmt.Errorf("unexpected %q after quoted value", trailing)
+				}
+				return b.String(), rest, lines, nil
+			case c == '\\' && quote == '"' && i+1 < len(input):
+				i++
+				switch input[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(input[i])
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+
+		if rest == "" {
+			return "", "", 0, fmt.Errorf("unterminated quoted value")
+		}
+		b.WriteByte('\n')
+		lines++
+		input, rest, _ = strings.Cut(rest, "\n")
+	}
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDotEnv(t *testing.T) {
+	envContent := `# Application settings
+APP_NAME=test-app
+export PORT=8080
+DEBUG=true # inline comment
+
+DATABASE_HOST="localhost"
+DATABASE_PORT=5432
+DATABASE_USERNAME='admin'
+DATABASE_PASSWORD="multi
+line\tsecret"
+`
+
+	tmpDir := t.TempDir()
+	envFile := filepath.Join(tmpDir, "config.env")
+	if err := os.WriteFile(envFile, []byte(envContent), 0644); err != nil {
+		t.Fatalf("Failed to create test .env file: %v", err)
+	}
+
+	config, err := LoadDotEnv[TestConfig](envFile)
+	if err != nil {
+		t.Fatalf("LoadDotEnv failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+	if config.Port != 8080 {
+		t.Errorf("Expected Port 8080, got %d", config.Port)
+	}
+	if !config.Debug {
+		t.Error("Expected Debug to be true")
+	}
+	if config.Database.Host != "localhost" {
+		t.Errorf("Expected Database.Host 'localhost', got '%s'", config.Database.Host)
+	}
+	if config.Database.Port != 5432 {
+		t.Errorf("Expected Database.Port 5432, got %d", config.Database.Port)
+	}
+	if config.Database.Username != "admin" {
+		t.Errorf("Expected Database.Username 'admin', got '%s'", config.Database.Username)
+	}
+	if config.Database.Password != "multi\nline\tsecret" {
+		t.Errorf("Expected multi-line Database.Password, got %q", config.Database.Password)
+	}
+}
+
+func TestLoadAutoDetectDotEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	envFile := filepath.Join(tmpDir, ".env")
+	if err := os.WriteFile(envFile, []byte("APP_NAME=test-app\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test .env file: %v", err)
+	}
+
+	config, err := Load[TestConfig](envFile)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+}
+
+func TestLoadDotEnvInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing equals", content: "APP_NAME\n"},
+		{name: "unterminated quote", content: "APP_NAME=\"test-app\nPORT=8080\n"},
+		{name: "text after quote", content: "APP_NAME='test' app\n"},
+		{name: "invalid int", content: "PORT=abc\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			envFile := filepath.Join(tmpDir, ".env")
+			if err := os.WriteFile(envFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create test .env file: %v", err)
+			}
+
+			if _, err := LoadDotEnv[TestConfig](envFile); err == nil {
+				t.Fatal("Expected error for invalid .env file, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadDotEnvFileNotFound(t *testing.T) {
+	_, err := LoadDotEnv[TestConfig]("nonexistent.env")
+	if err == nil {
+		t.Fatal("Expected error for non-existent file, got nil")
+	}
+}
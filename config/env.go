@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoadWithEnv loads the config file at path like Load, then overrides fields
+// from environment variables. Variable names are the prefix followed by the
+// field's json (or yaml or toml) tag names, upper-cased and joined by underscores, so
+// with prefix "APP" the field Database.Password tagged "database" and
+// "password" is read from APP_DATABASE_PASSWORD. Environment variables take
+// precedence over values in the file.
+func LoadWithEnv[T any](path, prefix string) (T, error) {
+	config, err := Load[T](path)
+	if err != nil {
+		return config, err
+	}
+
+	v := reflect.ValueOf(&config).Elem()
+	if v.Kind() != reflect.Struct {
+		return config, nil
+	}
+	if err := applyEnv(v, envPrefix(prefix)); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// envPrefix normalizes prefix to upper case without a trailing underscore
+func envPrefix(prefix string) string {
+	return strings.ToUpper(strings.TrimSuffix(prefix, "_"))
+}
+
+// envName joins a prefix and a field name into an environment variable name
+func envName(prefix, name string) string {
+	name = strings.ToUpper(name)
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+// applyEnv sets the fields of the struct v from environment variables named
+// after prefix and their tag names, recursing into nested structs
+func applyEnv(v reflect.Value, prefix string) error {
+	return applyVars(v, prefix, os.LookupEnv)
+}
+
+// applyVars sets the fields of the struct v from the variables returned by
+// lookup, named like applyEnv
+func applyVars(v reflect.Value, prefix string, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, ok := fieldName(field)
+		if !ok {
+			continue
+		}
+
+		fv := v.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			// Untagged embedded structs are flattened, as encoding/json does
+			nested := envName(prefix, name)
+			if field.Anonymous && !hasTagName(field) {
+				nested = prefix
+			}
+			if err := applyVars(fv, nested, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key := envName(prefix, name)
+		value, ok := lookup(key)
+		if !ok {
+			continue
+		}
+		if err := setValue(fv, value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// nameTags are the struct tags that name a field in config files, in order
+// of preference
+var nameTags = []string{"json", "yaml", "toml"}
+
+// fieldName returns the name of a struct field as it appears in config files:
+// its json, yaml or toml tag name, else the Go field name. It reports false
+// for fields tagged "-".
+func fieldName(field reflect.StructField) (string, bool) {
+	for _, key := range nameTags {
+		tag, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			return "", false
+		}
+		if name != "" {
+			return name, true
+		}
+	}
+	return field.Name, true
+}
+
+// hasTagName reports whether one of nameTags gives the field a name
+func hasTagName(field reflect.StructField) bool {
+	for _, key := range nameTags {
+		if name, _, _ := strings.Cut(field.Tag.Get(key), ","); name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue parses s into v, which must be a string, bool, integer, float or
+// time.Duration (given as a duration string such as "5s")
+func setValue(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadWithEnv(t *testing.T) {
+	jsonContent := `{
+		"app_name": "test-app",
+		"port": 8080,
+		"debug": false,
+		"database": {
+			"host": "localhost",
+			"password": "from-file"
+		}
+	}`
+
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(jsonFile, []byte(jsonContent), 0644); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+
+	t.Setenv("APP_PORT", "9090")
+	t.Setenv("APP_DEBUG", "true")
+	t.Setenv("APP_DATABASE_PASSWORD", "from-env")
+
+	config, err := LoadWithEnv[TestConfig](jsonFile, "APP")
+	if err != nil {
+		t.Fatalf("LoadWithEnv failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+	if config.Port != 9090 {
+		t.Errorf("Expected Port 9090, got %d", config.Port)
+	}
+	if !config.Debug {
+		t.Error("Expected Debug to be true")
+	}
+	if config.Database.Host != "localhost" {
+		t.Errorf("Expected Database.Host 'localhost', got '%s'", config.Database.Host)
+	}
+	if config.Database.Password != "from-env" {
+		t.Errorf("Expected Database.Password 'from-env', got '%s'", config.Database.Password)
+	}
+}
+
+func TestLoadWithEnvInvalidValue(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(jsonFile, []byte(`{"port": 8080}`), 0644); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+
+	t.Setenv("APP_PORT", "not-a-number")
+
+	_, err := LoadWithEnv[TestConfig](jsonFile, "APP_")
+	if err == nil {
+		t.Fatal("Expected error for invalid env value, got nil")
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	type Embedded struct {
+		Region string `json:"region"`
+	}
+	type Config struct {
+		Embedded
+		Timeout time.Duration `yaml:"timeout"`
+		Ratio   float64
+		Workers uint   `json:"workers,omitempty"`
+		Ignored string `json:"-"`
+	}
+
+	t.Setenv("SVC_REGION", "eu-west-1")
+	t.Setenv("SVC_TIMEOUT", "5s")
+	t.Setenv("SVC_RATIO", "0.5")
+	t.Setenv("SVC_WORKERS", "4")
+	t.Setenv("SVC_IGNORED", "set")
+
+	var config Config
+	if err := applyEnv(reflect.ValueOf(&config).Elem(), "SVC"); err != nil {
+		t.Fatalf("applyEnv failed: %v", err)
+	}
+
+	if config.Region != "eu-west-1" {
+		t.Errorf("Expected Region 'eu-west-1', got '%s'", config.Region)
+	}
+	if config.Timeout != 5*time.Second {
+		t.Errorf("Expected Timeout 5s, got %v", config.Timeout)
+	}
+	if config.Ratio != 0.5 {
+		t.Errorf("Expected Ratio 0.5, got %v", config.Ratio)
+	}
+	if config.Workers != 4 {
+		t.Errorf("Expected Workers 4, got %d", config.Workers)
+	}
+	if config.Ignored != "" {
+		t.Errorf("Expected Ignored to stay empty, got '%s'", config.Ignored)
+	}
+}
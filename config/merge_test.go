@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMerged(t *testing.T) {
+	baseContent := `{
+		"app_name": "test-app",
+		"port": 8080,
+		"database": {
+			"host": "localhost",
+			"port": 5432,
+			"username": "admin"
+		},
+		"endpoints": ["/api/v1", "/api/v2"],
+		"metadata": {
+			"version": "1.0.0",
+			"environment": "development"
+		}
+	}`
+	overrideContent := `{
+		"port": 9090,
+		"database": {
+			"host": "db.prod.internal"
+		},
+		"endpoints": ["/api/v3"],
+		"metadata": {
+			"environment": "production"
+		}
+	}`
+
+	tmpDir := t.TempDir()
+	baseFile := filepath.Join(tmpDir, "base.json")
+	overrideFile := filepath.Join(tmpDir, "production.json")
+	if err := os.WriteFile(baseFile, []byte(baseContent), 0644); err != nil {
+		t.Fatalf("Failed to create base JSON file: %v", err)
+	}
+	if err := os.WriteFile(overrideFile, []byte(overrideContent), 0644); err != nil {
+		t.Fatalf("Failed to create override JSON file: %v", err)
+	}
+
+	config, err := LoadMerged[TestConfig](baseFile, overrideFile)
+	if err != nil {
+		t.Fatalf("LoadMerged failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+	if config.Port != 9090 {
+		t.Errorf("Expected Port 9090, got %d", config.Port)
+	}
+	if config.Database.Host != "db.prod.internal" {
+		t.Errorf("Expected Database.Host 'db.prod.internal', got '%s'", config.Database.Host)
+	}
+	if config.Database.Port != 5432 {
+		t.Errorf("Expected Database.Port 5432, got %d", config.Database.Port)
+	}
+	if config.Database.Username != "admin" {
+		t.Errorf("Expected Database.Username 'admin', got '%s'", config.Database.Username)
+	}
+	if len(config.Endpoints) != 1 || config.Endpoints[0] != "/api/v3" {
+		t.Errorf("Expected Endpoints to be replaced with [/api/v3], got %v", config.Endpoints)
+	}
+	if config.Metadata["version"] != "1.0.0" {
+		t.Errorf("Expected metadata version '1.0.0', got '%s'", config.Metadata["version"])
+	}
+	if config.Metadata["environment"] != "production" {
+		t.Errorf("Expected metadata environment 'production', got '%s'", config.Metadata["environment"])
+	}
+}
+
+func TestLoadMergedMixedFormats(t *testing.T) {
+	yamlContent := `app_name: test-app
+database:
+  host: localhost
+  port: 5432
+endpoints:
+  - /api/v1
+metadata:
+  version: 1.0.0
+`
+
+	tmpDir := t.TempDir()
+	baseFile := filepath.Join(tmpDir, "base.yaml")
+	overrideFile := filepath.Join(tmpDir, ".env")
+	if err := os.WriteFile(baseFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create base YAML file: %v", err)
+	}
+	if err := os.WriteFile(overrideFile, []byte("DATABASE_HOST=db.prod.internal\n"), 0644); err != nil {
+		t.Fatalf("Failed to create override .env file: %v", err)
+	}
+
+	config, err := LoadMerged[TestConfig](baseFile, overrideFile)
+	if err != nil {
+		t.Fatalf("LoadMerged failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+	if config.Database.Host != "db.prod.internal" {
+		t.Errorf("Expected Database.Host 'db.prod.internal', got '%s'", config.Database.Host)
+	}
+	if config.Database.Port != 5432 {
+		t.Errorf("Expected Database.Port 5432, got %d", config.Database.Port)
+	}
+	if config.Metadata["version"] != "1.0.0" {
+		t.Errorf("Expected metadata version '1.0.0', got '%s'", config.Metadata["version"])
+	}
+}
+
+func TestLoadMergedYAMLMaps(t *testing.T) {
+	tmpDir := t.TempDir()
+	baseFile := filepath.Join(tmpDir, "base.yaml")
+	overrideFile := filepath.Join(tmpDir, "override.yml")
+	if err := os.WriteFile(baseFile, []byte("metadata:\n  version: 1.0.0\n  environment: development\n"), 0644); err != nil {
+		t.Fatalf("Failed to create base YAML file: %v", err)
+	}
+	if err := os.WriteFile(overrideFile, []byte("metadata:\n  environment: production\n"), 0644); err != nil {
+		t.Fatalf("Failed to create override YAML file: %v", err)
+	}
+
+	config, err := LoadMerged[TestConfig](baseFile, overrideFile)
+	if err != nil {
+		t.Fatalf("LoadMerged failed: %v", err)
+	}
+
+	if config.Metadata["version"] != "1.0.0" || config.Metadata["environment"] != "production" {
+		t.Errorf("Expected metadata to be merged key by key, got %v", config.Metadata)
+	}
+}
+
+func TestLoadMergedErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	baseFile := filepath.Join(tmpDir, "base.json")
+	if err := os.WriteFile(baseFile, []byte(`{"port": 8080}`), 0644); err != nil {
+		t.Fatalf("Failed to create base JSON file: %v", err)
+	}
+
+	if _, err := LoadMerged[TestConfig](baseFile, filepath.Join(tmpDir, "missing.json")); err == nil {
+		t.Error("Expected error for missing override file, got nil")
+	}
+	if _, err := LoadMerged[TestConfig](baseFile, filepath.Join(tmpDir, "override.txt")); err == nil {
+		t.Error("Expected error for unsupported format, got nil")
+	}
+}
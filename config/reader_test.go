@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadJSONBytes(t *testing.T) {
+	config, err := LoadJSONBytes[TestConfig]([]byte(`{"app_name": "test-app", "port": 8080, "database": {"host": "localhost"}}`))
+	if err != nil {
+		t.Fatalf("LoadJSONBytes failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+	if config.Port != 8080 {
+		t.Errorf("Expected Port 8080, got %d", config.Port)
+	}
+	if config.Database.Host != "localhost" {
+		t.Errorf("Expected Database.Host 'localhost', got '%s'", config.Database.Host)
+	}
+}
+
+func TestLoadYAMLBytes(t *testing.T) {
+	config, err := LoadYAMLBytes[TestConfig]([]byte("app_name: test-app\nport: 8080\n"))
+	if err != nil {
+		t.Fatalf("LoadYAMLBytes failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+	if config.Port != 8080 {
+		t.Errorf("Expected Port 8080, got %d", config.Port)
+	}
+}
+
+func TestLoadJSONReader(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config.json": {Data: []byte(`{"app_name": "embedded-app"}`)},
+	}
+	f, err := fsys.Open("config.json")
+	if err != nil {
+		t.Fatalf("Failed to open embedded file: %v", err)
+	}
+	defer f.Close()
+
+	config, err := LoadJSONReader[TestConfig](f)
+	if err != nil {
+		t.Fatalf("LoadJSONReader failed: %v", err)
+	}
+
+	if config.AppName != "embedded-app" {
+		t.Errorf("Expected AppName 'embedded-app', got '%s'", config.AppName)
+	}
+}
+
+func TestLoadYAMLReader(t *testing.T) {
+	config, err := LoadYAMLReader[TestConfig](strings.NewReader("app_name: test-app\n"))
+	if err != nil {
+		t.Fatalf("LoadYAMLReader failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func TestLoadReaderErrors(t *testing.T) {
+	if _, err := LoadJSONReader[TestConfig](errReader{}); err == nil {
+		t.Error("Expected error for failing reader, got nil")
+	}
+	if _, err := LoadJSONBytes[TestConfig]([]byte(`{"port": }`)); err == nil {
+		t.Error("Expected error for invalid JSON, got nil")
+	}
+	if _, err := LoadYAMLBytes[TestConfig]([]byte("port: [")); err == nil {
+		t.Error("Expected error for invalid YAML, got nil")
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTOML(t *testing.T) {
+	// Create temporary TOML file
+	tomlContent := `app_name = "test-app"
+port = 8080
+debug = true
+endpoints = ["/api/v1", "/api/v2"]
+
+[database]
+host = "localhost"
+port = 5432
+username = "admin"
+password = "secret"
+
+[metadata]
+version = "1.0.0"
+environment = "test"
+`
+
+	tmpDir := t.TempDir()
+	tomlFile := filepath.Join(tmpDir, "config.toml")
+	if err := os.WriteFile(tomlFile, []byte(tomlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test TOML file: %v", err)
+	}
+
+	config, err := LoadTOML[TestConfig](tomlFile)
+	if err != nil {
+		t.Fatalf("LoadTOML failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+	if config.Port != 8080 {
+		t.Errorf("Expected Port 8080, got %d", config.Port)
+	}
+	if !config.Debug {
+		t.Error("Expected Debug to be true")
+	}
+	if config.Database.Host != "localhost" {
+		t.Errorf("Expected Database.Host 'localhost', got '%s'", config.Database.Host)
+	}
+	if len(config.Endpoints) != 2 {
+		t.Errorf("Expected 2 endpoints, got %d", len(config.Endpoints))
+	}
+	if config.Metadata["version"] != "1.0.0" {
+		t.Errorf("Expected metadata version '1.0.0', got '%s'", config.Metadata["version"])
+	}
+}
+
+func TestLoadAutoDetectTOML(t *testing.T) {
+	tomlContent := `app_name = "test-app"
+port = 8080
+`
+
+	tmpDir := t.TempDir()
+	tomlFile := filepath.Join(tmpDir, "config.toml")
+	if err := os.WriteFile(tomlFile, []byte(tomlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test TOML file: %v", err)
+	}
+
+	config, err := Load[TestConfig](tomlFile)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if config.AppName != "test-app" {
+		t.Errorf("Expected AppName 'test-app', got '%s'", config.AppName)
+	}
+}
+
+func TestLoadTOMLFileNotFound(t *testing.T) {
+	_, err := LoadTOML[TestConfig]("nonexistent.toml")
+	if err == nil {
+		t.Fatal("Expected error for non-existent file, got nil")
+	}
+}
+
+func TestLoadTOMLInvalidTOML(t *testing.T) {
+	invalidTOML := `app_name = "test-app
+port = 8080
+`
+
+	tmpDir := t.TempDir()
+	tomlFile := filepath.Join(tmpDir, "config.toml")
+	if err := os.WriteFile(tomlFile, []byte(invalidTOML), 0644); err != nil {
+		t.Fatalf("Failed to create test TOML file: %v", err)
+	}
+
+	_, err := LoadTOML[TestConfig](tomlFile)
+	if err == nil {
+		t.Fatal("Expected error for invalid TOML, got nil")
+	}
+}
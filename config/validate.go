@@ -0,0 +1,78 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrInvalidConfig indicates a config failed validation
+var ErrInvalidConfig = errors.New("invalid config")
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// Report fields by the names used in config files
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, ok := fieldName(field)
+		if !ok {
+			return "-"
+		}
+		return name
+	})
+	return v
+}
+
+// Validate checks cfg against its `validate` struct tags (e.g.
+// `validate:"required,min=1"`, see go-playground/validator). It returns an
+// error wrapping ErrInvalidConfig that lists every failing field.
+func Validate[T any](cfg T) error {
+	err := validate.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	msgs := make([]string, len(verrs))
+	for i, fe := range verrs {
+		msgs[i] = fieldError(fe)
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidConfig, strings.Join(msgs, "; "))
+}
+
+// LoadAndValidate loads the config file at path like Load, then validates it
+func LoadAndValidate[T any](path string) (T, error) {
+	config, err := Load[T](path)
+	if err != nil {
+		return config, err
+	}
+
+	if err := Validate(config); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// fieldError describes a failed check as "<path>: failed <tag> check",
+// where path is the dotted field path without the root type name
+func fieldError(fe validator.FieldError) string {
+	path := fe.Namespace()
+	if _, rest, ok := strings.Cut(path, "."); ok {
+		path = rest
+	}
+
+	check := fe.Tag()
+	if fe.Param() != "" {
+		check += "=" + fe.Param()
+	}
+	return fmt.Sprintf("%s: failed %s check", path, check)
+}
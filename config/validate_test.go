@@ -0,0 +1,87 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type ValidatedConfig struct {
+	AppName  string                  `json:"app_name" validate:"required"`
+	Port     int                     `json:"port" validate:"required,min=1,max=65535"`
+	Database ValidatedDatabaseConfig `json:"database"`
+}
+
+type ValidatedDatabaseConfig struct {
+	Host string `json:"host" validate:"required"`
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   ValidatedConfig
+		wantErr  bool
+		contains []string
+	}{
+		{
+			name:   "valid config",
+			config: ValidatedConfig{AppName: "test-app", Port: 8080, Database: ValidatedDatabaseConfig{Host: "localhost"}},
+		},
+		{
+			name:     "missing required field",
+			config:   ValidatedConfig{Port: 8080, Database: ValidatedDatabaseConfig{Host: "localhost"}},
+			wantErr:  true,
+			contains: []string{"app_name: failed required check"},
+		},
+		{
+			name:     "out of range int",
+			config:   ValidatedConfig{AppName: "test-app", Port: 70000, Database: ValidatedDatabaseConfig{Host: "localhost"}},
+			wantErr:  true,
+			contains: []string{"port: failed max=65535 check"},
+		},
+		{
+			name:     "every failing field is listed",
+			config:   ValidatedConfig{Port: 70000},
+			wantErr:  true,
+			contains: []string{"app_name: failed required check", "port: failed max=65535 check", "database.host: failed required check"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("Expected error to wrap ErrInvalidConfig, got %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to contain %q, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestLoadAndValidate(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(jsonFile, []byte(`{"app_name": "test-app", "database": {"host": "localhost"}}`), 0644); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+
+	_, err := LoadAndValidate[ValidatedConfig](jsonFile)
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Expected ErrInvalidConfig for missing port, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "port: failed required check") {
+		t.Errorf("Expected error to name the port field, got %q", err.Error())
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long Watch waits after the last change before
+// reloading, so the several writes an editor makes on save cause one reload
+const watchDebounce = 100 * time.Millisecond
+
+// Watch reloads the config file at path with Load whenever it changes and
+// calls onChange with the new value. If the file fails to load, the error is
+// logged and onChange is not called. Call stop to stop watching; it waits for
+// a running onChange to return, so onChange must not call stop itself.
+func Watch[T any](path string, onChange func(T)) (stop func(), err error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config watcher: %w", err)
+	}
+
+	// Watch the directory rather than the file: editors often save by
+	// writing a new file and renaming it over the old one
+	path = filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch config file: %w", err)
+	}
+
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		var timer *time.Timer
+		var reload <-chan time.Time
+		for {
+			select {
+			case <-quit:
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != path || !(event.Has(fsnotify.Write) || event.Has(fsnotify.Create)) {
+					continue
+				}
+				if timer == nil {
+					timer = time.NewTimer(watchDebounce)
+				} else {
+					timer.Reset(watchDebounce)
+				}
+				reload = timer.C
+			case <-reload:
+				reload = nil
+				config, err := Load[T](path)
+				if err != nil {
+					log.Printf("config: failed to reload %s: %v", path, err)
+					continue
+				}
+				onChange(config)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("config: error watching %s: %v", path, err)
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			close(quit)
+			<-done
+			watcher.Close()
+		})
+	}
+	return stop, nil
+}
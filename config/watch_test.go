@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(jsonFile, []byte(`{"port": 8080}`), 0644); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+
+	changes := make(chan TestConfig, 10)
+	stop, err := Watch(jsonFile, func(config TestConfig) {
+		changes <- config
+	})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer stop()
+
+	// Rapid successive writes are debounced into one reload
+	for _, port := range []string{"9001", "9002", "9003"} {
+		if err := os.WriteFile(jsonFile, []byte(`{"port": `+port+`}`), 0644); err != nil {
+			t.Fatalf("Failed to write test JSON file: %v", err)
+		}
+	}
+
+	select {
+	case config := <-changes:
+		if config.Port != 9003 {
+			t.Errorf("Expected Port 9003, got %d", config.Port)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected onChange to be called after writing the file")
+	}
+
+	select {
+	case config := <-changes:
+		t.Errorf("Expected a single reload, got another with Port %d", config.Port)
+	case <-time.After(3 * watchDebounce):
+	}
+
+	// Invalid content is skipped
+	if err := os.WriteFile(jsonFile, []byte(`{"port": }`), 0644); err != nil {
+		t.Fatalf("Failed to write test JSON file: %v", err)
+	}
+	select {
+	case config := <-changes:
+		t.Errorf("Expected invalid config to be skipped, got Port %d", config.Port)
+	case <-time.After(3 * watchDebounce):
+	}
+
+	// Replacing the file by rename is picked up
+	tmpFile := filepath.Join(tmpDir, "config.json.tmp")
+	if err := os.WriteFile(tmpFile, []byte(`{"port": 9004}`), 0644); err != nil {
+		t.Fatalf("Failed to write temp JSON file: %v", err)
+	}
+	if err := os.Rename(tmpFile, jsonFile); err != nil {
+		t.Fatalf("Failed to rename temp JSON file: %v", err)
+	}
+	select {
+	case config := <-changes:
+		if config.Port != 9004 {
+			t.Errorf("Expected Port 9004, got %d", config.Port)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected onChange to be called after replacing the file")
+	}
+}
+
+func TestWatchStop(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(jsonFile, []byte(`{"port": 8080}`), 0644); err != nil {
+		t.Fatalf("Failed to create test JSON file: %v", err)
+	}
+
+	changes := make(chan TestConfig, 10)
+	stop, err := Watch(jsonFile, func(config TestConfig) {
+		changes <- config
+	})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	stop()
+	stop()
+
+	if err := os.WriteFile(jsonFile, []byte(`{"port": 9090}`), 0644); err != nil {
+		t.Fatalf("Failed to write test JSON file: %v", err)
+	}
+	select {
+	case config := <-changes:
+		t.Errorf("Expected no reload after stop, got Port %d", config.Port)
+	case <-time.After(3 * watchDebounce):
+	}
+}
+
+func TestWatchMissingDirectory(t *testing.T) {
+	_, err := Watch(filepath.Join(t.TempDir(), "missing", "config.json"), func(TestConfig) {})
+	if err == nil {
+		t.Fatal("Expected error for missing directory, got nil")
+	}
+}
@@ -0,0 +1,28 @@
+package grace
+
+import (
+	"net/http"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// ServeAutocert serves handler over HTTPS with certificates obtained
+// automatically from Let's Encrypt and cached in cacheDir. Only hosts
+// allowed by hostPolicy are issued certificates.
+func ServeAutocert(addr string, handler http.Handler, hostPolicy autocert.HostPolicy, cacheDir string, opts ...Option) error {
+	server := newAutocertServer(addr, handler, hostPolicy, cacheDir)
+	return ServeServerTLSWith(server, "", "", opts...)
+}
+
+func newAutocertServer(addr string, handler http.Handler, hostPolicy autocert.HostPolicy, cacheDir string) *http.Server {
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: hostPolicy,
+		Cache:      autocert.DirCache(cacheDir),
+	}
+	return &http.Server{
+		Addr:      addr,
+		Handler:   handler,
+		TLSConfig: m.TLSConfig(),
+	}
+}
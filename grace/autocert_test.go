@@ -0,0 +1,23 @@
+package grace
+
+import (
+	"net/http"
+	"testing"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+func TestNewAutocertServer(t *testing.T) {
+	handler := http.NewServeMux()
+	server := newAutocertServer(":8443", handler, autocert.HostWhitelist("example.com"), t.TempDir())
+
+	if server.Addr != ":8443" {
+		t.Errorf("Expected addr :8443, got %s", server.Addr)
+	}
+	if server.Handler != handler {
+		t.Error("Expected handler to be set")
+	}
+	if server.TLSConfig == nil || server.TLSConfig.GetCertificate == nil {
+		t.Fatal("Expected TLSConfig.GetCertificate to be set")
+	}
+}
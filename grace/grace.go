@@ -1,8 +1,7 @@
 package grace
 
 import (
-	"context"
-	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -27,40 +26,110 @@ func ServeHTTPS(addr, certFile, keyFile string, handler http.Handler) error {
 }
 
 func ServeServer(server *http.Server) error {
-	go func() {
-		log.Printf("Starting HTTP server on %s", server.Addr)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Printf("HTTP server error: %v", err)
-		}
-	}()
-	return waitForShutdown(server)
+	return ServeServerSignals(server)
+}
+
+// ServeServerSignals is like ServeServer but shuts down only on the given
+// signals. With no signals it listens for SIGINT and SIGTERM.
+func ServeServerSignals(server *http.Server, signals ...os.Signal) error {
+	return ServeServerWith(server, WithSignals(signals...))
+}
+
+// ServeServerWith serves server over HTTP and shuts it down gracefully
+// according to opts.
+func ServeServerWith(server *http.Server, opts ...Option) error {
+	return New(server, opts...).Serve()
+}
+
+// ServeServerWithTimeout is like ServeServer but waits up to timeout for
+// active requests to finish before closing them
+func ServeServerWithTimeout(server *http.Server, timeout time.Duration) error {
+	return ServeServerWith(server, WithShutdownTimeout(timeout))
+}
+
+// Serve serves server over HTTP and shuts it down gracefully according to
+// opts. It is shorthand for ServeServerWith.
+func Serve(server *http.Server, opts ...Option) error {
+	return ServeServerWith(server, opts...)
 }
 
 func ServeServerTLS(server *http.Server, certFile, keyFile string) error {
-	go func() {
-		log.Printf("Starting HTTPS server on %s", server.Addr)
-		if err := server.ListenAndServeTLS(certFile, keyFile); err != nil && err != http.ErrServerClosed {
-			log.Printf("HTTPS server error: %v", err)
-		}
-	}()
-	return waitForShutdown(server)
+	return ServeServerTLSWith(server, certFile, keyFile)
+}
+
+// ServeServerTLSWith serves server over HTTPS and shuts it down gracefully
+// according to opts.
+func ServeServerTLSWith(server *http.Server, certFile, keyFile string, opts ...Option) error {
+	s := New(server, opts...)
+	s.name = "HTTPS"
+	s.defaultAddr = ":https"
+	s.serveFn = func(lis net.Listener) error {
+		return server.ServeTLS(lis, certFile, keyFile)
+	}
+	return s.Serve()
+}
+
+// ServeListener serves handler on a caller-provided listener, such as one
+// inherited through systemd socket activation, and shuts down gracefully.
+func ServeListener(lis net.Listener, handler http.Handler, opts ...Option) error {
+	opts = append(opts, WithListener(lis))
+	return New(&http.Server{Handler: handler}, opts...).Serve()
 }
 
-func waitForShutdown(server *http.Server) error {
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+type signalWaiter struct {
+	quit   chan os.Signal
+	reload chan os.Signal
+}
+
+// notifySignals starts listening for shutdown (and, with OnReload, reload)
+// signals. It is called before the server starts so no signal is missed.
+func notifySignals(cfg *config) *signalWaiter {
+	w := &signalWaiter{quit: make(chan os.Signal, 1)}
+	signal.Notify(w.quit, cfg.shutdownSignals()...)
 
-	log.Println("Shutdown signal received...")
+	if cfg.onReload != nil {
+		w.reload = make(chan os.Signal, 1)
+		signal.Notify(w.reload, syscall.SIGHUP)
+	}
+	return w
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+func (w *signalWaiter) stop() {
+	signal.Stop(w.quit)
+	if w.reload != nil {
+		signal.Stop(w.reload)
+	}
+}
 
-	if err := server.Shutdown(ctx); err != nil {
-		log.Printf("Server forced shutdown: %v", err)
-		return err
+// wait blocks until a shutdown signal is received, trigger is closed, or the
+// server fails, in which case the server error is returned. After a signal it
+// marks the process not ready and waits out the drain delay.
+func (w *signalWaiter) wait(errCh <-chan error, trigger <-chan struct{}, cfg *config) error {
+	for waiting := true; waiting; {
+		select {
+		case err := <-errCh:
+			return err
+		case <-w.reload:
+			cfg.infof("Reload signal received...")
+			if err := cfg.onReload(); err != nil {
+				cfg.errorf(err, "Reload failed")
+			}
+		case sig := <-w.quit:
+			if cfg.onSignal != nil {
+				cfg.onSignal(sig)
+			}
+			waiting = false
+		case <-trigger:
+			waiting = false
+		}
 	}
 
-	log.Println("Server gracefully stopped")
+	cfg.infof("Shutdown signal received...")
+	MarkNotReady()
+
+	if cfg.drainDelay > 0 {
+		cfg.infof("Waiting %s before shutting down", cfg.drainDelay)
+		time.Sleep(cfg.drainDelay)
+	}
 	return nil
 }
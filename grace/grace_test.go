@@ -1,10 +1,23 @@
 package grace
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
+
+	"github.com/davidsugianto/go-pkgs/logger"
 )
 
 func TestServeHTTP(t *testing.T) {
@@ -27,5 +40,636 @@ func TestServeHTTP(t *testing.T) {
 }
 
 func TestGracefulShutdown(t *testing.T) {
-	t.Skip("Integration test - run manually")
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	addr := freeAddr(t)
+	server := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(entered)
+			<-release
+			fmt.Fprintln(w, "done")
+		}),
+	}
+
+	s := New(server)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve()
+	}()
+
+	respCh := make(chan int, 1)
+	go func() {
+		for {
+			resp, err := http.Get("http://" + addr)
+			if err != nil {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			resp.Body.Close()
+			respCh <- resp.StatusCode
+			return
+		}
+	}()
+	<-entered
+
+	s.Shutdown()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Serve returned before the active request finished: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+
+	if code := <-respCh; code != http.StatusOK {
+		t.Errorf("Expected active request to complete with 200, got %d", code)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Expected clean shutdown, got %v", err)
+	}
+}
+
+func TestServeServerReturnsListenError(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer lis.Close()
+
+	server := &http.Server{Addr: lis.Addr().String()}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeServer(server)
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Expected bind error, got nil")
+		}
+		if !strings.Contains(err.Error(), "address already in use") {
+			t.Errorf("Expected address already in use error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeServer did not return after bind failure")
+	}
+}
+
+func TestServeServerSignals(t *testing.T) {
+	// Catch SIGUSR2 ourselves so delivering it cannot terminate the test binary.
+	ignored := make(chan os.Signal, 1)
+	signal.Notify(ignored, syscall.SIGUSR2)
+	defer signal.Stop(ignored)
+
+	addr := freeAddr(t)
+	server := &http.Server{Addr: addr}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeServerSignals(server, syscall.SIGUSR1)
+	}()
+	waitServing(t, addr)
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatalf("Failed to send SIGUSR2: %v", err)
+	}
+	<-ignored
+
+	select {
+	case err := <-done:
+		t.Fatalf("Server stopped on unconfigured signal: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not stop on configured signal")
+	}
+}
+
+func TestServeServerWithOnSignal(t *testing.T) {
+	// Serve twice in one process to check the first serve stopped listening
+	for i := 0; i < 2; i++ {
+		received := make(chan os.Signal, 1)
+		ready := make(chan struct{})
+		done := make(chan error, 1)
+		go func() {
+			done <- ServeServerWith(&http.Server{Addr: "127.0.0.1:0"},
+				WithSignals(syscall.SIGUSR1),
+				OnReady(func() { close(ready) }),
+				OnSignal(func(sig os.Signal) { received <- sig }),
+			)
+		}()
+		<-ready
+
+		if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+			t.Fatalf("Failed to send SIGUSR1: %v", err)
+		}
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Expected clean shutdown, got %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Server did not stop on SIGUSR1")
+		}
+		if sig := <-received; sig != syscall.SIGUSR1 {
+			t.Errorf("Expected OnSignal to receive SIGUSR1, got %v", sig)
+		}
+	}
+}
+
+func TestServeServerWithPreShutdownDelay(t *testing.T) {
+	delay := 300 * time.Millisecond
+	server := &http.Server{Addr: "127.0.0.1:0"}
+
+	shutdownAt := make(chan time.Time, 1)
+	server.RegisterOnShutdown(func() {
+		shutdownAt <- time.Now()
+	})
+
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeServerWith(server,
+			WithSignals(syscall.SIGUSR1),
+			WithPreShutdownDelay(delay),
+			OnReady(func() { close(ready) }),
+		)
+	}()
+	<-ready
+
+	signaledAt := time.Now()
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not stop")
+	}
+
+	if elapsed := (<-shutdownAt).Sub(signaledAt); elapsed < delay {
+		t.Errorf("Expected shutdown to begin after %s, began after %s", delay, elapsed)
+	}
+}
+
+func TestServeServerWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := logger.NewWithConfig(logger.Config{Output: &buf})
+
+	server := &http.Server{Addr: "127.0.0.1:0"}
+
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeServerWith(server,
+			WithSignals(syscall.SIGUSR1),
+			WithLogger(l),
+			OnReady(func() { close(ready) }),
+		)
+	}()
+	<-ready
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not stop")
+	}
+
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Log line is not JSON: %q", line)
+		}
+		if entry["message"] == "Shutdown signal received..." {
+			found = true
+			if entry["level"] != "info" {
+				t.Errorf("Expected level info, got %v", entry["level"])
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected shutdown message in logs, got %s", buf.String())
+	}
+}
+
+func TestServeServerWithPrintfLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := log.New(&buf, "", 0)
+
+	server := &http.Server{Addr: "127.0.0.1:0"}
+
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeServerWith(server,
+			WithSignals(syscall.SIGUSR1),
+			WithPrintfLogger(l),
+			OnReady(func() { close(ready) }),
+		)
+	}()
+	<-ready
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not stop")
+	}
+
+	for _, want := range []string{"Starting HTTP server on 127.0.0.1:", "Shutdown signal received...", "Server gracefully stopped"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q in logs, got %s", want, buf.String())
+		}
+	}
+}
+
+func TestServeListener(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := lis.Addr().String()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "Hello, World!")
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeListener(lis, handler, WithSignals(syscall.SIGUSR1))
+	}()
+
+	resp, err := http.Get("http://" + addr)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeListener did not return after signal")
+	}
+
+	if _, err := net.Dial("tcp", addr); err == nil {
+		t.Error("Expected listener to be closed after shutdown")
+	}
+}
+
+func TestServeServerWithOnReady(t *testing.T) {
+	addr := freeAddr(t)
+	server := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, "Hello, World!")
+		}),
+	}
+
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeServerWith(server,
+			WithSignals(syscall.SIGUSR1),
+			OnReady(func() { close(ready) }),
+		)
+	}()
+
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Ready callback was not called")
+	}
+
+	resp, err := http.Get("http://" + addr)
+	if err != nil {
+		t.Fatalf("Request right after ready failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not stop")
+	}
+}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve port: %v", err)
+	}
+	defer lis.Close()
+	return lis.Addr().String()
+}
+
+// waitServing waits until a server accepts connections on addr, for serve
+// functions that take no OnReady option. Signal handlers are registered
+// before the listener is bound, so a signal sent afterwards is never missed.
+func waitServing(t *testing.T, addr string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Server on %s did not start: %v", addr, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServeServerWithOnShutdown(t *testing.T) {
+	t.Run("timely", func(t *testing.T) {
+		server := &http.Server{Addr: "127.0.0.1:0"}
+
+		statsCh := make(chan ShutdownStats, 1)
+		ready := make(chan struct{})
+		done := make(chan error, 1)
+		go func() {
+			done <- ServeServerWith(server,
+				WithSignals(syscall.SIGUSR1),
+				OnReady(func() { close(ready) }),
+				OnShutdown(func(s ShutdownStats) { statsCh <- s }),
+			)
+		}()
+		<-ready
+
+		if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+			t.Fatalf("Failed to send SIGUSR1: %v", err)
+		}
+		if err := <-done; err != nil {
+			t.Errorf("Expected clean shutdown, got %v", err)
+		}
+
+		stats := <-statsCh
+		if stats.Forced {
+			t.Error("Expected shutdown not to be forced")
+		}
+	})
+
+	t.Run("forced", func(t *testing.T) {
+		entered := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+
+		addr := freeAddr(t)
+		server := &http.Server{
+			Addr: addr,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(entered)
+				<-release
+			}),
+		}
+
+		statsCh := make(chan ShutdownStats, 1)
+		ready := make(chan struct{})
+		done := make(chan error, 1)
+		go func() {
+			done <- ServeServerWith(server,
+				WithSignals(syscall.SIGUSR1),
+				WithShutdownTimeout(100*time.Millisecond),
+				OnReady(func() { close(ready) }),
+				OnShutdown(func(s ShutdownStats) { statsCh <- s }),
+			)
+		}()
+		<-ready
+
+		go http.Get("http://" + addr)
+		<-entered
+
+		if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+			t.Fatalf("Failed to send SIGUSR1: %v", err)
+		}
+		if err := <-done; !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded, got %v", err)
+		}
+
+		stats := <-statsCh
+		if !stats.Forced {
+			t.Error("Expected shutdown to be forced")
+		}
+		if stats.Duration < 100*time.Millisecond {
+			t.Errorf("Expected duration of at least the timeout, got %s", stats.Duration)
+		}
+	})
+}
+
+func TestServeServerWithOnReload(t *testing.T) {
+	reloaded := make(chan struct{}, 2)
+	calls := 0
+	onReload := func() error {
+		calls++
+		reloaded <- struct{}{}
+		if calls == 1 {
+			return errors.New("bad config")
+		}
+		return nil
+	}
+
+	server := &http.Server{Addr: "127.0.0.1:0"}
+
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeServerWith(server,
+			WithSignals(syscall.SIGUSR1),
+			OnReady(func() { close(ready) }),
+			OnReload(onReload),
+		)
+	}()
+	<-ready
+
+	for i := 0; i < 2; i++ {
+		if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+			t.Fatalf("Failed to send SIGHUP: %v", err)
+		}
+		select {
+		case <-reloaded:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Reload callback was not called")
+		}
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("Server stopped on reload: %v", err)
+	default:
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Expected clean shutdown, got %v", err)
+	}
+}
+
+func TestServeServerWithDrainDelayAndShutdownTimeout(t *testing.T) {
+	drainDelay := 200 * time.Millisecond
+	shutdownTimeout := 200 * time.Millisecond
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	addr := freeAddr(t)
+	server := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(entered)
+			<-release
+		}),
+	}
+
+	shutdownAt := make(chan time.Time, 1)
+	server.RegisterOnShutdown(func() {
+		shutdownAt <- time.Now()
+	})
+
+	statsCh := make(chan ShutdownStats, 1)
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeServerWith(server,
+			WithSignals(syscall.SIGUSR1),
+			WithDrainDelay(drainDelay),
+			WithShutdownTimeout(shutdownTimeout),
+			OnReady(func() { close(ready) }),
+			OnShutdown(func(s ShutdownStats) { statsCh <- s }),
+		)
+	}()
+	<-ready
+
+	go http.Get("http://" + addr)
+	<-entered
+
+	signaledAt := time.Now()
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	if err := <-done; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	stoppedAt := time.Now()
+
+	if drained := (<-shutdownAt).Sub(signaledAt); drained < drainDelay {
+		t.Errorf("Expected drain phase of at least %s, got %s", drainDelay, drained)
+	}
+	if total := stoppedAt.Sub(signaledAt); total < drainDelay+shutdownTimeout {
+		t.Errorf("Expected total shutdown of at least %s, got %s", drainDelay+shutdownTimeout, total)
+	}
+
+	stats := <-statsCh
+	if stats.Duration < shutdownTimeout {
+		t.Errorf("Expected shutdown phase of at least %s, got %s", shutdownTimeout, stats.Duration)
+	}
+	if !stats.Forced {
+		t.Error("Expected shutdown to be forced after the timeout")
+	}
+}
+
+func TestServeShutdownTimeout(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	addr := freeAddr(t)
+	server := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(entered)
+			<-release
+		}),
+	}
+
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(server,
+			WithSignals(syscall.SIGUSR1),
+			WithShutdownTimeout(100*time.Millisecond),
+			OnReady(func() { close(ready) }),
+		)
+	}()
+	<-ready
+
+	go http.Get("http://" + addr)
+	<-entered
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrShutdownTimeout) {
+			t.Errorf("Expected ErrShutdownTimeout, got %v", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected error to still wrap context.DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after the shutdown timeout")
+	}
 }
@@ -0,0 +1,91 @@
+// Package gracetest provides helpers for testing graceful shutdown without
+// sending real OS signals.
+package gracetest
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/davidsugianto/go-pkgs/grace"
+)
+
+// cleanupTimeout is how long the test cleanup waits for the server to stop
+const cleanupTimeout = 10 * time.Second
+
+// Server is a grace.Server running on a random local port
+type Server struct {
+	// URL is the base URL of the server, e.g. http://127.0.0.1:41234
+	URL string
+
+	server   *grace.Server
+	stopped  chan struct{}
+	err      error // set by Serve before stopped is closed
+	returned bool  // whether Shutdown already returned err to the test
+}
+
+// Start serves handler with grace on a random local port. If Shutdown was
+// not called, the server is shut down when the test finishes and the test
+// fails if it does not stop in time or stops with an error.
+func Start(t testing.TB, handler http.Handler, opts ...grace.Option) *Server {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("gracetest: failed to listen: %v", err)
+	}
+
+	ready := make(chan struct{})
+	opts = append(opts,
+		grace.WithListener(lis),
+		grace.OnReady(func() { close(ready) }),
+	)
+
+	s := &Server{
+		URL:     "http://" + lis.Addr().String(),
+		server:  grace.New(&http.Server{Handler: handler}, opts...),
+		stopped: make(chan struct{}),
+	}
+	go func() {
+		s.err = s.server.Serve()
+		close(s.stopped)
+	}()
+
+	select {
+	case <-ready:
+	case <-s.stopped:
+		t.Fatalf("gracetest: server failed to start: %v", s.err)
+	}
+
+	t.Cleanup(func() {
+		s.server.Shutdown()
+		select {
+		case <-s.stopped:
+			if !s.returned && s.err != nil && !errors.Is(s.err, http.ErrServerClosed) {
+				t.Errorf("gracetest: server stopped with error: %v", s.err)
+			}
+		case <-time.After(cleanupTimeout):
+			t.Errorf("gracetest: server did not stop within %s", cleanupTimeout)
+		}
+	})
+	return s
+}
+
+// Shutdown triggers a graceful shutdown and waits for it to complete,
+// returning the error from Serve. It fails the test if shutdown does not
+// complete within timeout.
+func (s *Server) Shutdown(t testing.TB, timeout time.Duration) error {
+	t.Helper()
+
+	s.server.Shutdown()
+	select {
+	case <-s.stopped:
+		s.returned = true
+		return s.err
+	case <-time.After(timeout):
+		t.Fatalf("gracetest: shutdown did not complete within %s", timeout)
+		return nil
+	}
+}
@@ -0,0 +1,68 @@
+package gracetest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/davidsugianto/go-pkgs/grace"
+)
+
+func TestStartAndShutdown(t *testing.T) {
+	s := Start(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "hello")
+	}))
+
+	resp, err := http.Get(s.URL)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(body) != "hello" {
+		t.Errorf("Expected body hello, got %q", body)
+	}
+
+	if err := s.Shutdown(t, 5*time.Second); err != nil {
+		t.Errorf("Expected clean shutdown, got %v", err)
+	}
+
+	if _, err := net.Dial("tcp", strings.TrimPrefix(s.URL, "http://")); err == nil {
+		t.Error("Expected listener to be closed after shutdown")
+	}
+}
+
+func TestShutdownRunsHooks(t *testing.T) {
+	hookErr := errors.New("flush failed")
+	s := Start(t, http.NotFoundHandler(),
+		grace.WithShutdownHook(func(ctx context.Context) error { return hookErr }),
+	)
+
+	err := s.Shutdown(t, 5*time.Second)
+	if !errors.Is(err, hookErr) {
+		t.Errorf("Expected hook error, got %v", err)
+	}
+}
+
+func TestCleanupWaitsForServe(t *testing.T) {
+	var stopped atomic.Bool
+	t.Run("server", func(t *testing.T) {
+		Start(t, http.NotFoundHandler(),
+			grace.WithShutdownHook(func(ctx context.Context) error {
+				stopped.Store(true)
+				return nil
+			}),
+		)
+	})
+
+	if !stopped.Load() {
+		t.Error("Expected cleanup to wait for the server to finish shutting down")
+	}
+}
@@ -0,0 +1,84 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/net/netutil"
+	"google.golang.org/grpc"
+)
+
+// ServeGRPC serves server on lis and stops it gracefully on a shutdown
+// signal. If in-flight RPCs do not finish within the shutdown timeout the
+// server is stopped forcibly and a *ShutdownError wrapping
+// ErrShutdownTimeout (and context.DeadlineExceeded) is returned.
+//
+// lis may be nil when WithListener is given. Options that configure an
+// http.Server, such as WithReadTimeout, return ErrUnsupportedOption.
+func ServeGRPC(lis net.Listener, server *grpc.Server, opts ...Option) error {
+	cfg := newConfig(opts)
+	if name := cfg.httpServerOption(); name != "" {
+		return fmt.Errorf("%w by ServeGRPC: %s", ErrUnsupportedOption, name)
+	}
+	switch {
+	case lis == nil && cfg.listener == nil:
+		return errors.New("ServeGRPC requires a listener")
+	case lis != nil && cfg.listener != nil:
+		return fmt.Errorf("%w by ServeGRPC: WithListener together with a listener argument", ErrUnsupportedOption)
+	case lis == nil:
+		lis = cfg.listener
+	}
+	if cfg.maxConns > 0 {
+		lis = netutil.LimitListener(lis, cfg.maxConns)
+	}
+
+	signals := notifySignals(cfg)
+	defer signals.stop()
+
+	errCh := make(chan error, 1)
+	cfg.logStart("gRPC", lis.Addr())
+	go func() {
+		if err := server.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+			cfg.errorf(err, "gRPC server error")
+			errCh <- err
+		}
+	}()
+	if cfg.onReady != nil {
+		cfg.onReady()
+	}
+
+	if err := signals.wait(errCh, nil, cfg); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	var errs []error
+	forced := false
+	select {
+	case <-stopped:
+	case <-time.After(cfg.shutdownTimeout):
+		server.Stop()
+		forced = true
+		cfg.errorf(context.DeadlineExceeded, "gRPC server forced shutdown")
+		errs = append(errs, fmt.Errorf("%w: %w", ErrShutdownTimeout, context.DeadlineExceeded))
+	}
+
+	errs = append(errs, cfg.runShutdownHooks(context.Background())...)
+	cfg.reportShutdown(ShutdownStats{Duration: time.Since(start), Forced: forced})
+
+	if len(errs) > 0 {
+		return &ShutdownError{Errors: errs}
+	}
+
+	cfg.infof("gRPC server gracefully stopped")
+	return nil
+}
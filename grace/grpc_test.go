@@ -0,0 +1,172 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialBufconn returns a client connection to lis
+func dialBufconn(t *testing.T, lis *bufconn.Listener) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestServeGRPC(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeGRPC(lis, server, WithSignals(syscall.SIGUSR1))
+	}()
+
+	conn := dialBufconn(t, lis)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Health check failed: %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Expected SERVING, got %v", resp.Status)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected graceful stop, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeGRPC did not return after signal")
+	}
+}
+
+func TestServeGRPCShutdownTimeout(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeGRPC(lis, server,
+			WithSignals(syscall.SIGUSR1),
+			WithShutdownTimeout(100*time.Millisecond),
+		)
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A Watch stream stays open, so GracefulStop cannot finish in time
+	stream, err := healthpb.NewHealthClient(dialBufconn(t, lis)).Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Watch did not start: %v", err)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrShutdownTimeout) {
+			t.Errorf("Expected ErrShutdownTimeout, got %v", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected error to still wrap context.DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeGRPC did not return after the shutdown timeout")
+	}
+}
+
+func TestServeGRPCOptions(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	hookRan := false
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeGRPC(nil, server,
+			WithListener(lis),
+			WithMaxConns(10),
+			WithSignals(syscall.SIGUSR1),
+			OnReady(func() { close(ready) }),
+			WithShutdownHook(func(ctx context.Context) error {
+				if ctx.Err() != nil {
+					t.Errorf("Expected a live hook context, got %v", ctx.Err())
+				}
+				hookRan = true
+				return nil
+			}),
+		)
+	}()
+	<-ready
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := healthpb.NewHealthClient(dialBufconn(t, lis)).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Health check failed: %v", err)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected graceful stop, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeGRPC did not return after signal")
+	}
+	if !hookRan {
+		t.Error("Expected the shutdown hook to run")
+	}
+}
+
+func TestServeGRPCUnsupportedOption(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	defer lis.Close()
+
+	err := ServeGRPC(lis, grpc.NewServer(), WithReadTimeout(time.Second))
+	if !errors.Is(err, ErrUnsupportedOption) {
+		t.Errorf("Expected ErrUnsupportedOption, got %v", err)
+	}
+}
@@ -0,0 +1,21 @@
+package grace
+
+import (
+	"net/http"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// ServeH2C serves handler over HTTP/2 cleartext (h2c), still accepting
+// HTTP/1.1, and shuts down gracefully.
+func ServeH2C(addr string, handler http.Handler, opts ...Option) error {
+	return ServeServerWith(newH2CServer(addr, handler), opts...)
+}
+
+func newH2CServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:    addr,
+		Handler: h2c.NewHandler(handler, &http2.Server{}),
+	}
+}
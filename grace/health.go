@@ -0,0 +1,47 @@
+package grace
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/davidsugianto/go-pkgs/response"
+)
+
+// HealthCheck reports whether a dependency is healthy
+type HealthCheck func(ctx context.Context) error
+
+type healthReport struct {
+	Status string        `json:"status"`
+	Checks []checkResult `json:"checks,omitempty"`
+}
+
+type checkResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HealthHandler runs checks and responds 200 with a JSON summary when all of
+// them pass, or 503 when any fails. Once shutdown has begun it responds 503
+// without running the checks, like ReadinessHandler.
+func HealthHandler(checks ...HealthCheck) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if notReady.Load() {
+			response.JSON(w, http.StatusServiceUnavailable, healthReport{Status: "shutting_down"})
+			return
+		}
+
+		report := healthReport{Status: "ok"}
+		statusCode := http.StatusOK
+		for _, check := range checks {
+			result := checkResult{Status: "ok"}
+			if err := check(r.Context()); err != nil {
+				result = checkResult{Status: "fail", Error: err.Error()}
+				report.Status = "fail"
+				statusCode = http.StatusServiceUnavailable
+			}
+			report.Checks = append(report.Checks, result)
+		}
+
+		response.JSON(w, statusCode, report)
+	})
+}
@@ -0,0 +1,78 @@
+package grace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serveHealth(t *testing.T, h http.Handler) (int, healthReport) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	var body struct {
+		Data healthReport `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return rec.Code, body.Data
+}
+
+func TestHealthHandlerPassing(t *testing.T) {
+	MarkReady()
+
+	ok := func(ctx context.Context) error { return nil }
+	code, report := serveHealth(t, HealthHandler(ok, ok))
+
+	if code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", code)
+	}
+	if report.Status != "ok" {
+		t.Errorf("Expected status ok, got %s", report.Status)
+	}
+	if len(report.Checks) != 2 {
+		t.Errorf("Expected 2 check results, got %d", len(report.Checks))
+	}
+}
+
+func TestHealthHandlerFailing(t *testing.T) {
+	MarkReady()
+
+	ok := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("database unreachable") }
+	code, report := serveHealth(t, HealthHandler(ok, failing))
+
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", code)
+	}
+	if report.Status != "fail" {
+		t.Errorf("Expected status fail, got %s", report.Status)
+	}
+	if report.Checks[1].Error != "database unreachable" {
+		t.Errorf("Expected check error to be reported, got %q", report.Checks[1].Error)
+	}
+}
+
+func TestHealthHandlerShuttingDown(t *testing.T) {
+	MarkNotReady()
+	defer MarkReady()
+
+	called := false
+	check := func(ctx context.Context) error { called = true; return nil }
+	code, report := serveHealth(t, HealthHandler(check))
+
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", code)
+	}
+	if report.Status != "shutting_down" {
+		t.Errorf("Expected status shutting_down, got %s", report.Status)
+	}
+	if called {
+		t.Error("Expected checks not to run during shutdown")
+	}
+}
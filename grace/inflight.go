@@ -0,0 +1,23 @@
+package grace
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+var inFlight atomic.Int64
+
+// TrackInFlight counts requests currently being handled by next. The count
+// is available through InFlight and is reported when shutdown begins.
+func TrackInFlight(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight.Add(1)
+		defer inFlight.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// InFlight returns the number of requests currently tracked by TrackInFlight
+func InFlight() int64 {
+	return inFlight.Load()
+}
@@ -0,0 +1,75 @@
+package grace
+
+import (
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestTrackInFlight(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	addr := freeAddr(t)
+	server := &http.Server{
+		Addr: addr,
+		Handler: TrackInFlight(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(entered)
+			<-release
+			w.WriteHeader(http.StatusOK)
+		})),
+	}
+
+	shuttingDown := make(chan struct{})
+	server.RegisterOnShutdown(func() { close(shuttingDown) })
+
+	statsCh := make(chan ShutdownStats, 1)
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeServerWith(server,
+			WithSignals(syscall.SIGUSR1),
+			OnReady(func() { close(ready) }),
+			OnShutdown(func(s ShutdownStats) { statsCh <- s }),
+		)
+	}()
+	<-ready
+
+	respCh := make(chan int, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr)
+		if err != nil {
+			respCh <- 0
+			return
+		}
+		resp.Body.Close()
+		respCh <- resp.StatusCode
+	}()
+	<-entered
+
+	if n := InFlight(); n != 1 {
+		t.Errorf("Expected 1 request in flight, got %d", n)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+	<-shuttingDown
+	close(release)
+
+	if code := <-respCh; code != http.StatusOK {
+		t.Errorf("Expected in-flight request to complete with 200, got %d", code)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Expected clean shutdown, got %v", err)
+	}
+
+	stats := <-statsCh
+	if stats.InFlight != 1 {
+		t.Errorf("Expected 1 request in flight at shutdown, got %d", stats.InFlight)
+	}
+	if n := InFlight(); n != 0 {
+		t.Errorf("Expected 0 requests in flight after shutdown, got %d", n)
+	}
+}
@@ -0,0 +1,20 @@
+package grace
+
+import "net/http"
+
+// MaxBodyBytes limits request bodies to n bytes. Requests that declare a
+// larger Content-Length are rejected with 413 Request Entity Too Large;
+// otherwise the body is wrapped with http.MaxBytesReader so reading past
+// the limit fails with *http.MaxBytesError.
+func MaxBodyBytes(n int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > n {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, n)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
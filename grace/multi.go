@@ -0,0 +1,111 @@
+package grace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ServeMulti serves each server over HTTP and, on SIGINT/SIGTERM, shuts them
+// all down concurrently within the shared shutdown timeout. Use it to run,
+// say, an API server next to a metrics server.
+func ServeMulti(servers ...*http.Server) error {
+	return ServeMultiWith(servers)
+}
+
+// ServeMultiWith is like ServeMulti but configured by opts. Shutdown hooks,
+// OnReady and OnShutdown run once for the whole group; WithListener returns
+// ErrUnsupportedOption. If a server fails to start or stops unexpectedly,
+// the others are shut down and its error is returned.
+func ServeMultiWith(servers []*http.Server, opts ...Option) error {
+	cfg := newConfig(opts)
+	if cfg.listener != nil {
+		return fmt.Errorf("%w by ServeMultiWith: WithListener", ErrUnsupportedOption)
+	}
+	signals := notifySignals(cfg)
+	defer signals.stop()
+
+	// Each server gets the shared settings but none of the group-level
+	// callbacks, and all of them report failures on one channel
+	serverCfg := *cfg
+	serverCfg.onReady = nil
+	serverCfg.onShutdown = nil
+	serverCfg.shutdownHooks = nil
+
+	errCh := make(chan error, len(servers))
+	group := make([]*Server, 0, len(servers))
+	for _, server := range servers {
+		c := serverCfg
+		s := newServer(server, &c)
+		s.errCh = errCh
+		if err := s.Start(); err != nil {
+			closeAll(group, cfg)
+			return err
+		}
+		group = append(group, s)
+	}
+	if cfg.onReady != nil {
+		cfg.onReady()
+	}
+
+	if err := signals.wait(errCh, nil, cfg); err != nil {
+		closeAll(group, cfg)
+		return err
+	}
+	return stopAll(group, cfg)
+}
+
+// stopAll stops every server concurrently within the shutdown timeout, then
+// runs the shutdown hooks. Every failure is collected into a *ShutdownError.
+func stopAll(group []*Server, cfg *config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.shutdownTimeout)
+	defer cancel()
+
+	pending := InFlight()
+	cfg.infof("Shutting down %d servers with %d requests in flight", len(group), pending)
+
+	start := time.Now()
+	errs := make([]error, len(group))
+	forced := make([]bool, len(group))
+	var wg sync.WaitGroup
+	for i, s := range group {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			forced[i], errs[i] = s.closeServer(ctx)
+		}()
+	}
+	wg.Wait()
+
+	var shutdownErrs []error
+	for _, err := range errs {
+		if err != nil {
+			shutdownErrs = append(shutdownErrs, err)
+		}
+	}
+	shutdownErrs = append(shutdownErrs, cfg.runShutdownHooks(ctx)...)
+	cfg.reportShutdown(ShutdownStats{
+		Duration: time.Since(start),
+		Forced:   slices.Contains(forced, true),
+		InFlight: pending,
+	})
+
+	if len(shutdownErrs) > 0 {
+		return &ShutdownError{Errors: shutdownErrs}
+	}
+
+	cfg.infof("Servers gracefully stopped")
+	return nil
+}
+
+// closeAll closes servers that were started before the group failed
+func closeAll(group []*Server, cfg *config) {
+	for _, s := range group {
+		if err := s.server.Close(); err != nil {
+			cfg.errorf(err, "%s server close error", s.name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestServeMultiWith(t *testing.T) {
+	addrs := []string{freeAddr(t), freeAddr(t)}
+	servers := make([]*http.Server, len(addrs))
+	for i, addr := range addrs {
+		servers[i] = &http.Server{
+			Addr: addr,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+		}
+	}
+
+	hooks := 0
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeMultiWith(servers,
+			WithSignals(syscall.SIGUSR1),
+			OnReady(func() { close(ready) }),
+			WithShutdownHook(func(ctx context.Context) error {
+				hooks++
+				return nil
+			}),
+		)
+	}()
+	<-ready
+
+	for _, addr := range addrs {
+		resp, err := http.Get("http://" + addr)
+		if err != nil {
+			t.Fatalf("Expected %s to be serving, got %v", addr, err)
+		}
+		resp.Body.Close()
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeMultiWith did not return after the shutdown signal")
+	}
+
+	for _, addr := range addrs {
+		if _, err := http.Get("http://" + addr); err == nil {
+			t.Errorf("Expected %s to be stopped", addr)
+		}
+	}
+	if hooks != 1 {
+		t.Errorf("Expected shutdown hook to run once, ran %d times", hooks)
+	}
+}
+
+func TestServeMultiReturnsListenError(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer lis.Close()
+
+	first := &http.Server{Addr: freeAddr(t)}
+	second := &http.Server{Addr: lis.Addr().String()}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeMultiWith([]*http.Server{first, second}, WithSignals(syscall.SIGUSR1))
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Expected bind error, got nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeMultiWith did not return after bind failure")
+	}
+
+	if _, err := http.Get("http://" + first.Addr); err == nil {
+		t.Error("Expected the started server to be closed after the bind failure")
+	}
+}
+
+func TestServeMultiWithRejectsListener(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer lis.Close()
+
+	err = ServeMultiWith([]*http.Server{{Addr: freeAddr(t)}}, WithListener(lis))
+	if !errors.Is(err, ErrUnsupportedOption) {
+		t.Errorf("Expected ErrUnsupportedOption, got %v", err)
+	}
+}
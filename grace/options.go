@@ -0,0 +1,335 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/davidsugianto/go-pkgs/logger"
+)
+
+const defaultShutdownTimeout = 30 * time.Second
+
+type config struct {
+	signals         []os.Signal
+	drainDelay      time.Duration
+	logger          *logger.Logger
+	printfLogger    Logger
+	onReady         func()
+	onSignal        func(os.Signal)
+	onShutdown      func(ShutdownStats)
+	shutdownTimeout time.Duration
+	onReload        func() error
+	shutdownHooks   []func(context.Context) error
+	baseContext     func(net.Listener) context.Context
+	connState       func(net.Conn, http.ConnState)
+	maxConns        int
+	listener        net.Listener
+	maxBodyBytes    int64
+
+	readTimeout       time.Duration
+	readHeaderTimeout time.Duration
+	writeTimeout      time.Duration
+	idleTimeout       time.Duration
+}
+
+// Option configures how a server is served and shut down
+type Option func(*config)
+
+// WithSignals sets the signals that trigger shutdown (default: SIGINT, SIGTERM)
+func WithSignals(signals ...os.Signal) Option {
+	return func(c *config) {
+		c.signals = signals
+	}
+}
+
+// WithDrainDelay waits for d after the shutdown signal before the server
+// stops accepting connections, giving load balancers time to notice the
+// failing readiness probe and stop routing traffic to it.
+func WithDrainDelay(d time.Duration) Option {
+	return func(c *config) {
+		c.drainDelay = d
+	}
+}
+
+// WithPreShutdownDelay is an alias for WithDrainDelay
+func WithPreShutdownDelay(d time.Duration) Option {
+	return WithDrainDelay(d)
+}
+
+// WithShutdownTimeout sets how long active requests get to finish once the
+// server stops accepting connections (default: 30s). It starts after the
+// drain delay, so the total shutdown budget is drain delay + timeout.
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.shutdownTimeout = d
+	}
+}
+
+// WithLogger sends lifecycle messages to l instead of the standard library log package
+func WithLogger(l *logger.Logger) Option {
+	return func(c *config) {
+		c.logger = l
+	}
+}
+
+// Logger is the minimal interface for printf-style loggers such as
+// *log.Logger
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// WithPrintfLogger sends lifecycle messages to l instead of the standard
+// library log package. WithLogger takes precedence if both are set.
+func WithPrintfLogger(l Logger) Option {
+	return func(c *config) {
+		c.printfLogger = l
+	}
+}
+
+// OnSignal registers fn to be called with the signal that triggered
+// shutdown, before the drain delay. It is not called when shutdown is
+// triggered by Server.Shutdown.
+func OnSignal(fn func(os.Signal)) Option {
+	return func(c *config) {
+		c.onSignal = fn
+	}
+}
+
+// OnReady registers fn to be called once the server's listener is bound and
+// connections can be accepted
+func OnReady(fn func()) Option {
+	return func(c *config) {
+		c.onReady = fn
+	}
+}
+
+// OnShutdown registers fn to receive stats once shutdown has completed
+func OnShutdown(fn func(ShutdownStats)) Option {
+	return func(c *config) {
+		c.onShutdown = fn
+	}
+}
+
+// OnReload registers fn to be called on SIGHUP while the server keeps
+// serving. Errors returned by fn are logged and do not stop the server.
+func OnReload(fn func() error) Option {
+	return func(c *config) {
+		c.onReload = fn
+	}
+}
+
+// WithShutdownHook registers fn to run after the server has stopped, e.g.
+// to close database pools. Hooks run in registration order and all of them
+// run even if some fail.
+func WithShutdownHook(fn func(ctx context.Context) error) Option {
+	return func(c *config) {
+		c.shutdownHooks = append(c.shutdownHooks, fn)
+	}
+}
+
+// WithBaseContext sets the function that provides the base context for
+// incoming requests, e.g. to carry shared values or cancellation
+func WithBaseContext(fn func(net.Listener) context.Context) Option {
+	return func(c *config) {
+		c.baseContext = fn
+	}
+}
+
+// WithConnState registers fn to observe client connection state changes
+func WithConnState(fn func(net.Conn, http.ConnState)) Option {
+	return func(c *config) {
+		c.connState = fn
+	}
+}
+
+// WithListener serves on lis instead of binding the server's Addr
+func WithListener(lis net.Listener) Option {
+	return func(c *config) {
+		c.listener = lis
+	}
+}
+
+// WithMaxConns caps the number of simultaneously accepted connections at n
+func WithMaxConns(n int) Option {
+	return func(c *config) {
+		c.maxConns = n
+	}
+}
+
+// WithMaxBodyBytes applies MaxBodyBytes(n) to every route of the server
+func WithMaxBodyBytes(n int64) Option {
+	return func(c *config) {
+		c.maxBodyBytes = n
+	}
+}
+
+// WithReadTimeout sets the server's ReadTimeout
+func WithReadTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.readTimeout = d
+	}
+}
+
+// WithReadHeaderTimeout sets the server's ReadHeaderTimeout
+func WithReadHeaderTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.readHeaderTimeout = d
+	}
+}
+
+// WithWriteTimeout sets the server's WriteTimeout
+func WithWriteTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.writeTimeout = d
+	}
+}
+
+// WithIdleTimeout sets the server's IdleTimeout
+func WithIdleTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.idleTimeout = d
+	}
+}
+
+// ErrUnsupportedOption is returned when an option does not apply to the
+// kind of server being served, e.g. an http.Server setting passed to ServeGRPC
+var ErrUnsupportedOption = errors.New("option not supported")
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		shutdownTimeout: defaultShutdownTimeout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// httpServerOption returns the name of the first http.Server-specific
+// option set, or "" if there is none
+func (c *config) httpServerOption() string {
+	switch {
+	case c.baseContext != nil:
+		return "WithBaseContext"
+	case c.connState != nil:
+		return "WithConnState"
+	case c.maxBodyBytes > 0:
+		return "WithMaxBodyBytes"
+	case c.readTimeout > 0:
+		return "WithReadTimeout"
+	case c.readHeaderTimeout > 0:
+		return "WithReadHeaderTimeout"
+	case c.writeTimeout > 0:
+		return "WithWriteTimeout"
+	case c.idleTimeout > 0:
+		return "WithIdleTimeout"
+	}
+	return ""
+}
+
+// applyTo copies server-level settings onto server
+func (c *config) applyTo(server *http.Server) {
+	if c.baseContext != nil {
+		server.BaseContext = c.baseContext
+	}
+	if c.connState != nil {
+		server.ConnState = c.connState
+	}
+	if c.readTimeout > 0 {
+		server.ReadTimeout = c.readTimeout
+	}
+	if c.readHeaderTimeout > 0 {
+		server.ReadHeaderTimeout = c.readHeaderTimeout
+	}
+	if c.writeTimeout > 0 {
+		server.WriteTimeout = c.writeTimeout
+	}
+	if c.idleTimeout > 0 {
+		server.IdleTimeout = c.idleTimeout
+	}
+	if c.maxBodyBytes > 0 {
+		handler := server.Handler
+		if handler == nil {
+			handler = http.DefaultServeMux
+		}
+		server.Handler = MaxBodyBytes(c.maxBodyBytes)(handler)
+	}
+}
+
+func (c *config) reportShutdown(stats ShutdownStats) {
+	if c.onShutdown != nil {
+		c.onShutdown(stats)
+	}
+}
+
+// runShutdownHooks runs the shutdown hooks in order and returns their
+// errors. The hooks get a fresh context bounded by the shutdown timeout,
+// keeping the values of ctx, since ctx has expired after a forced shutdown.
+func (c *config) runShutdownHooks(ctx context.Context) []error {
+	if len(c.shutdownHooks) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, hook := range c.shutdownHooks {
+		if err := hook(ctx); err != nil {
+			c.errorf(err, "Shutdown hook failed")
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (c *config) shutdownSignals() []os.Signal {
+	if len(c.signals) == 0 {
+		return []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+	return c.signals
+}
+
+func (c *config) infof(format string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Info().Msgf(format, args...)
+		return
+	}
+	c.printf(format, args...)
+}
+
+func (c *config) logStart(name string, addr net.Addr) {
+	if c.logger != nil {
+		c.logger.Info().
+			Str("protocol", name).
+			Str("network", addr.Network()).
+			Str("addr", addr.String()).
+			Msgf("Starting %s server on %s", name, addr)
+		return
+	}
+	c.printf("Starting %s server on %s", name, addr)
+}
+
+func (c *config) errorf(err error, format string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Error().Err(err).Msgf(format, args...)
+		return
+	}
+	c.printf("%s: %v", fmt.Sprintf(format, args...), err)
+}
+
+// printf writes a plain message to the printf logger, or to the standard
+// library log package if none is set
+func (c *config) printf(format string, args ...interface{}) {
+	if c.printfLogger != nil {
+		c.printfLogger.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
@@ -0,0 +1,32 @@
+package grace
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+var notReady atomic.Bool
+
+// ReadinessHandler responds 200 while the server is ready and 503 once a
+// shutdown signal has been received, so readiness probes fail and traffic
+// drains before connections are closed. Starting a server marks the process
+// ready again.
+func ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if notReady.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// MarkNotReady makes ReadinessHandler respond 503
+func MarkNotReady() {
+	notReady.Store(true)
+}
+
+// MarkReady makes ReadinessHandler respond 200
+func MarkReady() {
+	notReady.Store(false)
+}
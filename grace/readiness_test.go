@@ -0,0 +1,90 @@
+package grace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func readinessStatus() int {
+	rec := httptest.NewRecorder()
+	ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	return rec.Code
+}
+
+func TestReadinessHandler(t *testing.T) {
+	MarkReady()
+	defer MarkReady()
+
+	if code := readinessStatus(); code != http.StatusOK {
+		t.Errorf("Expected status 200 before shutdown, got %d", code)
+	}
+
+	MarkNotReady()
+	if code := readinessStatus(); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 after MarkNotReady, got %d", code)
+	}
+}
+
+func TestReadinessHandlerDuringShutdown(t *testing.T) {
+	defer MarkReady()
+
+	server := &http.Server{Addr: "127.0.0.1:0"}
+
+	ready := make(chan struct{})
+	signaled := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeServerWith(server,
+			WithSignals(syscall.SIGUSR1),
+			WithPreShutdownDelay(300*time.Millisecond),
+			OnReady(func() { close(ready) }),
+			OnSignal(func(os.Signal) { close(signaled) }),
+		)
+	}()
+	<-ready
+
+	if code := readinessStatus(); code != http.StatusOK {
+		t.Errorf("Expected status 200 while serving, got %d", code)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+	<-signaled
+
+	// Readiness flips right after OnSignal, well within the drain delay
+	code := readinessStatus()
+	for deadline := time.Now().Add(200 * time.Millisecond); code == http.StatusOK && time.Now().Before(deadline); {
+		time.Sleep(5 * time.Millisecond)
+		code = readinessStatus()
+	}
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 while shutting down, got %d", code)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not stop")
+	}
+}
+
+func TestReadinessHandlerAfterRestart(t *testing.T) {
+	// A shutdown earlier in the process must not leave later servers unready
+	MarkNotReady()
+
+	s := New(&http.Server{Addr: "127.0.0.1:0"})
+	if err := s.Start(); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+	defer s.Stop(context.Background())
+
+	if code := readinessStatus(); code != http.StatusOK {
+		t.Errorf("Expected status 200 after Start, got %d", code)
+	}
+}
@@ -0,0 +1,123 @@
+package grace
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+)
+
+// inheritEnv marks a process started by a graceful restart. The inherited
+// listener is passed as the first extra file, i.e. file descriptor 3.
+const inheritEnv = "GRACE_INHERIT_LISTENER"
+
+// ErrReloadWithRestart is returned by ServeGraceful when OnReload is set,
+// since both would be triggered by SIGHUP
+var ErrReloadWithRestart = errors.New("OnReload cannot be combined with ServeGraceful")
+
+// ServeGraceful serves handler on addr with zero-downtime restarts. On
+// SIGHUP the running binary is re-executed with the listening socket handed
+// over, so the new process accepts connections immediately while this one
+// drains its in-flight requests and exits. SIGINT/SIGTERM shut down as usual.
+//
+// Since SIGHUP triggers the restart it cannot be combined with OnReload;
+// ServeGraceful returns ErrReloadWithRestart if it is set.
+func ServeGraceful(addr string, handler http.Handler, opts ...Option) error {
+	if newConfig(opts).onReload != nil {
+		return ErrReloadWithRestart
+	}
+
+	lis, err := inheritedListener()
+	if err != nil {
+		return err
+	}
+	if lis == nil {
+		if addr == "" {
+			addr = ":http"
+		}
+		if lis, err = net.Listen("tcp", addr); err != nil {
+			return err
+		}
+	}
+
+	s := New(&http.Server{Addr: addr, Handler: handler}, opts...)
+	s.lis = lis
+
+	signals := notifySignals(s.cfg)
+	defer signals.stop()
+
+	restart := make(chan os.Signal, 1)
+	signal.Notify(restart, syscall.SIGHUP)
+	defer signal.Stop(restart)
+
+	if err := s.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-restart:
+				s.cfg.infof("Restart signal received, starting new process...")
+				proc, err := forkChild(lis, os.Args[1:])
+				if err != nil {
+					s.cfg.errorf(err, "Restart failed")
+					continue
+				}
+				s.cfg.infof("Started new process %d, draining", proc.Pid)
+				s.Shutdown()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	if err := signals.wait(s.errCh, s.shutdownCh, s.cfg); err != nil {
+		return err
+	}
+	return s.stopWithTimeout()
+}
+
+// forkChild re-executes the current binary with args, passing lis to it
+func forkChild(lis net.Listener, args []string) (*os.Process, error) {
+	fl, ok := lis.(interface{ File() (*os.File, error) })
+	if !ok {
+		return nil, errors.New("listener does not support file descriptor handoff")
+	}
+	f, err := fl.File()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	path, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(path, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), inheritEnv+"=1")
+	cmd.ExtraFiles = []*os.File{f}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return cmd.Process, nil
+}
+
+// inheritedListener returns the listener handed over by a parent process, or
+// nil if this process was not started by a graceful restart
+func inheritedListener() (net.Listener, error) {
+	if os.Getenv(inheritEnv) == "" {
+		return nil, nil
+	}
+	f := os.NewFile(3, "inherited-listener")
+	defer f.Close()
+	return net.FileListener(f)
+}
@@ -0,0 +1,99 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+const childTestEnv = "GRACE_TEST_CHILD"
+
+// TestGracefulChildProcess is the child side of TestForkChildInheritsListener.
+// It only runs when re-executed by that test.
+func TestGracefulChildProcess(t *testing.T) {
+	if os.Getenv(childTestEnv) == "" {
+		t.Skip("Only runs as a re-executed child process")
+	}
+
+	lis, err := inheritedListener()
+	if err != nil || lis == nil {
+		t.Fatalf("Expected inherited listener, got %v, %v", lis, err)
+	}
+
+	served := make(chan struct{}, 1)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("child"))
+			served <- struct{}{}
+		}),
+	}
+	go server.Serve(lis)
+
+	select {
+	case <-served:
+	case <-time.After(5 * time.Second):
+	}
+	server.Shutdown(context.Background())
+}
+
+func TestForkChildInheritsListener(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping process re-exec test")
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := lis.Addr().String()
+
+	t.Setenv(childTestEnv, "1")
+	proc, err := forkChild(lis, []string{"-test.run=^TestGracefulChildProcess$"})
+	if err != nil {
+		t.Fatalf("Failed to fork child: %v", err)
+	}
+
+	// The parent stops accepting; the socket stays open in the child.
+	lis.Close()
+
+	resp, err := http.Get("http://" + addr)
+	if err != nil {
+		t.Fatalf("Request to inherited listener failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(body) != "child" {
+		t.Errorf("Expected response from child, got %q", body)
+	}
+
+	state, err := proc.Wait()
+	if err != nil {
+		t.Fatalf("Failed to wait for child: %v", err)
+	}
+	if !state.Success() {
+		t.Errorf("Child exited with %s", state)
+	}
+}
+
+func TestInheritedListenerNotSet(t *testing.T) {
+	lis, err := inheritedListener()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lis != nil {
+		t.Error("Expected no inherited listener outside a restart")
+	}
+}
+
+func TestServeGracefulRejectsOnReload(t *testing.T) {
+	err := ServeGraceful("127.0.0.1:0", http.NotFoundHandler(), OnReload(func() error { return nil }))
+	if !errors.Is(err, ErrReloadWithRestart) {
+		t.Errorf("Expected ErrReloadWithRestart, got %v", err)
+	}
+}
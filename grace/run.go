@@ -0,0 +1,43 @@
+package grace
+
+import (
+	"context"
+	"os/signal"
+)
+
+// Run runs a non-HTTP worker with graceful shutdown. start is called with
+// ctx; if it returns an error, Run returns it immediately. Otherwise Run waits
+// for a shutdown signal or for ctx to be cancelled, then calls stop with a
+// context bounded by the shutdown timeout.
+func Run(ctx context.Context, start func(context.Context) error, stop func(context.Context) error, opts ...Option) error {
+	cfg := newConfig(opts)
+
+	ctx, cancel := signal.NotifyContext(ctx, cfg.shutdownSignals()...)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := start(ctx); err != nil {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	cfg.infof("Shutdown signal received...")
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), cfg.shutdownTimeout)
+	defer stopCancel()
+
+	if err := stop(stopCtx); err != nil {
+		cfg.errorf(err, "Worker forced shutdown")
+		return err
+	}
+
+	cfg.infof("Worker gracefully stopped")
+	return nil
+}
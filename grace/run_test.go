@@ -0,0 +1,81 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+
+	start := func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return nil
+	}
+	stop := func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("Expected stop context to have a deadline")
+		}
+		close(stopped)
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(ctx, start, stop)
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+
+	select {
+	case <-stopped:
+	default:
+		t.Error("Expected stop to be called")
+	}
+}
+
+func TestRunReturnsStartError(t *testing.T) {
+	startErr := errors.New("start failed")
+	stopCalled := false
+
+	err := Run(context.Background(),
+		func(ctx context.Context) error { return startErr },
+		func(ctx context.Context) error { stopCalled = true; return nil },
+	)
+	if !errors.Is(err, startErr) {
+		t.Errorf("Expected start error, got %v", err)
+	}
+	if stopCalled {
+		t.Error("Expected stop not to be called when start fails")
+	}
+}
+
+func TestRunReturnsStopError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	stopErr := errors.New("stop failed")
+	err := Run(ctx,
+		func(ctx context.Context) error { return nil },
+		func(ctx context.Context) error { return stopErr },
+	)
+	if !errors.Is(err, stopErr) {
+		t.Errorf("Expected stop error, got %v", err)
+	}
+}
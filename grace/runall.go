@@ -0,0 +1,25 @@
+package grace
+
+import (
+	"context"
+	"os/signal"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// RunAll runs each runner concurrently with a shared context that is
+// cancelled on SIGINT/SIGTERM, when ctx is cancelled, or as soon as any
+// runner returns an error. Runners are expected to return once their context
+// is done. RunAll waits for all of them and returns the first error.
+func RunAll(ctx context.Context, runners ...func(context.Context) error) error {
+	ctx, stop := signal.NotifyContext(ctx, newConfig(nil).shutdownSignals()...)
+	defer stop()
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, run := range runners {
+		g.Go(func() error {
+			return run(ctx)
+		})
+	}
+	return g.Wait()
+}
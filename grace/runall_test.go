@@ -0,0 +1,71 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunAllFailFast(t *testing.T) {
+	startErr := errors.New("bind failed")
+	var tornDown atomic.Int32
+
+	blocking := func(ctx context.Context) error {
+		<-ctx.Done()
+		tornDown.Add(1)
+		return nil
+	}
+	failing := func(ctx context.Context) error {
+		return startErr
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- RunAll(context.Background(), blocking, failing, blocking)
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, startErr) {
+			t.Errorf("Expected start error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunAll did not return after a runner failed")
+	}
+
+	if n := tornDown.Load(); n != 2 {
+		t.Errorf("Expected 2 runners torn down, got %d", n)
+	}
+}
+
+func TestRunAllCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var stopped atomic.Int32
+	runner := func(ctx context.Context) error {
+		<-ctx.Done()
+		stopped.Add(1)
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- RunAll(ctx, runner, runner)
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunAll did not return after cancellation")
+	}
+
+	if n := stopped.Load(); n != 2 {
+		t.Errorf("Expected 2 runners stopped, got %d", n)
+	}
+}
@@ -0,0 +1,167 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/netutil"
+)
+
+// Server manages the lifecycle of an http.Server. Use Serve for the usual
+// blocking behavior, or Start and Stop to control the lifecycle directly.
+type Server struct {
+	server      *http.Server
+	cfg         *config
+	name        string
+	defaultAddr string
+	serveFn     func(net.Listener) error
+	lis         net.Listener
+	errCh       chan error
+
+	shutdownCh   chan struct{}
+	shutdownOnce sync.Once
+}
+
+// New wraps server for graceful serving over HTTP
+func New(server *http.Server, opts ...Option) *Server {
+	return newServer(server, newConfig(opts))
+}
+
+func newServer(server *http.Server, cfg *config) *Server {
+	cfg.applyTo(server)
+
+	return &Server{
+		server:      server,
+		cfg:         cfg,
+		name:        "HTTP",
+		defaultAddr: ":http",
+		serveFn:     server.Serve,
+		lis:         cfg.listener,
+		errCh:       make(chan error, 1),
+		shutdownCh:  make(chan struct{}),
+	}
+}
+
+// Start binds the listener and serves in the background. It returns once
+// the server can accept connections, or with the error if binding failed.
+func (s *Server) Start() error {
+	if s.lis == nil {
+		addr := s.server.Addr
+		if addr == "" {
+			addr = s.defaultAddr
+		}
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			s.cfg.errorf(err, "%s server error", s.name)
+			return err
+		}
+		s.lis = lis
+	}
+	if s.cfg.maxConns > 0 {
+		s.lis = netutil.LimitListener(s.lis, s.cfg.maxConns)
+	}
+
+	s.cfg.logStart(s.name, s.lis.Addr())
+	go func() {
+		if err := s.serveFn(s.lis); err != nil && err != http.ErrServerClosed {
+			s.cfg.errorf(err, "%s server error", s.name)
+			s.errCh <- err
+		}
+	}()
+
+	// Readiness is process-wide, so clear a not-ready state left by an
+	// earlier shutdown in this process
+	MarkReady()
+	if s.cfg.onReady != nil {
+		s.cfg.onReady()
+	}
+	return nil
+}
+
+// Addr returns the address the server is listening on, or nil before Start.
+// When binding to port 0 it reports the port that was actually chosen.
+func (s *Server) Addr() net.Addr {
+	if s.lis == nil {
+		return nil
+	}
+	return s.lis.Addr()
+}
+
+// Stop gracefully shuts the server down, waiting for active requests until
+// ctx is done, then runs the shutdown hooks. If ctx expires first, remaining
+// connections are closed. Every failure is collected into a *ShutdownError.
+func (s *Server) Stop(ctx context.Context) error {
+	pending := InFlight()
+	s.cfg.infof("Shutting down with %d requests in flight", pending)
+
+	start := time.Now()
+	forced, err := s.closeServer(ctx)
+
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, s.cfg.runShutdownHooks(ctx)...)
+	s.cfg.reportShutdown(ShutdownStats{Duration: time.Since(start), Forced: forced, InFlight: pending})
+
+	if len(errs) > 0 {
+		return &ShutdownError{Errors: errs}
+	}
+
+	s.cfg.infof("Server gracefully stopped")
+	return nil
+}
+
+// closeServer shuts the http.Server down, closing remaining connections if
+// ctx is done first, and reports whether it had to
+func (s *Server) closeServer(ctx context.Context) (forced bool, err error) {
+	err = s.server.Shutdown(ctx)
+	forced = errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+	if forced {
+		s.server.Close()
+	}
+	if err != nil {
+		s.cfg.errorf(err, "Server forced shutdown")
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%w: %w", ErrShutdownTimeout, err)
+		}
+	}
+	return forced, err
+}
+
+// Serve starts the server and blocks until a shutdown signal is received,
+// then stops it within the shutdown timeout. It returns early with the
+// error if the server fails to start or stops unexpectedly.
+func (s *Server) Serve() error {
+	signals := notifySignals(s.cfg)
+	defer signals.stop()
+
+	if err := s.Start(); err != nil {
+		return err
+	}
+	if err := signals.wait(s.errCh, s.shutdownCh, s.cfg); err != nil {
+		return err
+	}
+	return s.stopWithTimeout()
+}
+
+// Shutdown makes a blocking Serve shut down as if a shutdown signal had been
+// received, including the drain delay. It returns immediately; Serve returns
+// once shutdown completes. Calling it more than once has no further effect.
+func (s *Server) Shutdown() {
+	s.shutdownOnce.Do(func() {
+		close(s.shutdownCh)
+	})
+}
+
+func (s *Server) stopWithTimeout() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.shutdownTimeout)
+	defer cancel()
+
+	return s.Stop(ctx)
+}
@@ -0,0 +1,330 @@
+package grace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidsugianto/go-pkgs/logger"
+)
+
+func TestServerStartStop(t *testing.T) {
+	addr := freeAddr(t)
+	server := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	}
+
+	s := New(server)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	resp, err := http.Get("http://" + addr)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.Stop(ctx); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	if _, err := net.Dial("tcp", addr); err == nil {
+		t.Error("Expected listener to be closed after Stop")
+	}
+}
+
+func TestServerStartBindError(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer lis.Close()
+
+	s := New(&http.Server{Addr: lis.Addr().String()})
+	if err := s.Start(); err == nil {
+		t.Error("Expected bind error, got nil")
+	}
+}
+
+type ctxKey struct{}
+
+func TestServerWithBaseContext(t *testing.T) {
+	addr := freeAddr(t)
+	server := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v, _ := r.Context().Value(ctxKey{}).(string)
+			w.Write([]byte(v))
+		}),
+	}
+
+	s := New(server, WithBaseContext(func(net.Listener) context.Context {
+		return context.WithValue(context.Background(), ctxKey{}, "correlation-123")
+	}))
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer s.Stop(context.Background())
+
+	resp, err := http.Get("http://" + addr)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "correlation-123" {
+		t.Errorf("Expected base context value correlation-123, got %q", body)
+	}
+}
+
+func TestServerWithConnState(t *testing.T) {
+	addr := freeAddr(t)
+	server := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	}
+
+	var mu sync.Mutex
+	var states []http.ConnState
+	s := New(server, WithConnState(func(_ net.Conn, state http.ConnState) {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, state)
+	}))
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	resp, err := http.Get("http://" + addr)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	resp.Body.Close()
+
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []http.ConnState{http.StateNew, http.StateActive, http.StateIdle}
+	if len(states) < len(want) {
+		t.Fatalf("Expected at least %v, got %v", want, states)
+	}
+	for i, state := range want {
+		if states[i] != state {
+			t.Errorf("Expected state %d to be %s, got %s", i, state, states[i])
+		}
+	}
+}
+
+func TestServerWithMaxConns(t *testing.T) {
+	addr := freeAddr(t)
+	release := make(chan struct{})
+	server := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}),
+	}
+
+	s := New(server, WithMaxConns(1))
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer s.Stop(context.Background())
+	defer close(release)
+
+	first, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer first.Close()
+	first.Write([]byte("GET / HTTP/1.1\r\nHost: test\r\n\r\n"))
+
+	second, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer second.Close()
+	second.Write([]byte("GET / HTTP/1.1\r\nHost: test\r\n\r\n"))
+
+	second.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if _, err := second.Read(make([]byte, 1)); err == nil {
+		t.Error("Expected second connection not to be served while the limit is reached")
+	}
+}
+
+func TestNewWithTimeouts(t *testing.T) {
+	server := &http.Server{}
+	New(server,
+		WithReadTimeout(5*time.Second),
+		WithReadHeaderTimeout(2*time.Second),
+		WithWriteTimeout(10*time.Second),
+		WithIdleTimeout(60*time.Second),
+	)
+
+	if server.ReadTimeout != 5*time.Second {
+		t.Errorf("Expected ReadTimeout 5s, got %s", server.ReadTimeout)
+	}
+	if server.ReadHeaderTimeout != 2*time.Second {
+		t.Errorf("Expected ReadHeaderTimeout 2s, got %s", server.ReadHeaderTimeout)
+	}
+	if server.WriteTimeout != 10*time.Second {
+		t.Errorf("Expected WriteTimeout 10s, got %s", server.WriteTimeout)
+	}
+	if server.IdleTimeout != 60*time.Second {
+		t.Errorf("Expected IdleTimeout 60s, got %s", server.IdleTimeout)
+	}
+}
+
+func TestNewKeepsServerTimeouts(t *testing.T) {
+	server := &http.Server{ReadTimeout: 3 * time.Second}
+	New(server, WithWriteTimeout(time.Second))
+
+	if server.ReadTimeout != 3*time.Second {
+		t.Errorf("Expected existing ReadTimeout to be kept, got %s", server.ReadTimeout)
+	}
+}
+
+func TestServerStopAggregatesHookErrors(t *testing.T) {
+	errCache := errors.New("cache close failed")
+	errDB := errors.New("db close failed")
+	var ran []string
+
+	s := New(&http.Server{Addr: freeAddr(t)},
+		WithShutdownHook(func(ctx context.Context) error {
+			ran = append(ran, "cache")
+			return errCache
+		}),
+		WithShutdownHook(func(ctx context.Context) error {
+			ran = append(ran, "queue")
+			return nil
+		}),
+		WithShutdownHook(func(ctx context.Context) error {
+			ran = append(ran, "db")
+			return errDB
+		}),
+	)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	err := s.Stop(context.Background())
+
+	var shutdownErr *ShutdownError
+	if !errors.As(err, &shutdownErr) {
+		t.Fatalf("Expected *ShutdownError, got %T: %v", err, err)
+	}
+	if len(shutdownErr.Errors) != 2 {
+		t.Errorf("Expected 2 errors, got %d", len(shutdownErr.Errors))
+	}
+	if !errors.Is(err, errCache) || !errors.Is(err, errDB) {
+		t.Errorf("Expected both hook errors, got %v", err)
+	}
+	if len(ran) != 3 {
+		t.Errorf("Expected all hooks to run, ran %v", ran)
+	}
+}
+
+func TestServerStopWithoutErrors(t *testing.T) {
+	s := New(&http.Server{Addr: freeAddr(t)},
+		WithShutdownHook(func(ctx context.Context) error { return nil }),
+	)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := s.Stop(context.Background()); err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+}
+
+func TestServerStopHookContextAfterTimeout(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	var hookErr error
+	s := New(&http.Server{
+		Addr: freeAddr(t),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		}),
+	}, WithShutdownHook(func(ctx context.Context) error {
+		hookErr = ctx.Err()
+		return nil
+	}))
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	go http.Get("http://" + s.Addr().String())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := s.Stop(ctx); !errors.Is(err, ErrShutdownTimeout) {
+		t.Fatalf("Expected ErrShutdownTimeout, got %v", err)
+	}
+	if hookErr != nil {
+		t.Errorf("Expected the hook to get a live context, got %v", hookErr)
+	}
+}
+
+func TestServerAddrEphemeralPort(t *testing.T) {
+	var buf bytes.Buffer
+	l := logger.NewWithConfig(logger.Config{Output: &buf})
+
+	s := New(&http.Server{Addr: "127.0.0.1:0"}, WithLogger(l))
+	if s.Addr() != nil {
+		t.Errorf("Expected nil Addr before Start, got %v", s.Addr())
+	}
+
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer s.Stop(context.Background())
+
+	addr, ok := s.Addr().(*net.TCPAddr)
+	if !ok {
+		t.Fatalf("Expected *net.TCPAddr, got %T", s.Addr())
+	}
+	if addr.Port == 0 {
+		t.Error("Expected a concrete port to be reported")
+	}
+
+	var entry map[string]interface{}
+	line, _, _ := strings.Cut(buf.String(), "\n")
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("Startup log is not JSON: %q", line)
+	}
+	if entry["addr"] != addr.String() {
+		t.Errorf("Expected logged addr %s, got %v", addr, entry["addr"])
+	}
+	if entry["protocol"] != "HTTP" {
+		t.Errorf("Expected logged protocol HTTP, got %v", entry["protocol"])
+	}
+}
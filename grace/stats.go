@@ -0,0 +1,39 @@
+package grace
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrShutdownTimeout is returned, inside a *ShutdownError, when the shutdown
+// timeout expires before active requests finish
+var ErrShutdownTimeout = errors.New("shutdown timed out")
+
+// ShutdownStats describes how a graceful shutdown went
+type ShutdownStats struct {
+	// Duration is how long it took to stop the server after shutdown began
+	Duration time.Duration
+
+	// Forced reports whether the shutdown timeout expired and remaining
+	// connections were closed forcibly
+	Forced bool
+
+	// InFlight is the number of requests tracked by TrackInFlight that were
+	// still being handled when shutdown began
+	InFlight int64
+}
+
+// ShutdownError collects every error that occurred while shutting down, so
+// one failure doesn't mask the others. It supports errors.Is and errors.As
+// against each collected error.
+type ShutdownError struct {
+	Errors []error
+}
+
+func (e *ShutdownError) Error() string {
+	return errors.Join(e.Errors...).Error()
+}
+
+func (e *ShutdownError) Unwrap() []error {
+	return e.Errors
+}
@@ -0,0 +1,106 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// WithCircuitBreaker stops sending requests after failureThreshold
+// consecutive failures (network errors and 5xx responses) and fails them
+// with ErrCircuitOpen instead. After openDuration a single trial request is
+// let through: success closes the circuit, failure opens it again. The
+// breaker is per Client and safe for concurrent use.
+func WithCircuitBreaker(failureThreshold int, openDuration time.Duration) Option {
+	return func(c *Client) {
+		c.breaker = &circuitBreaker{
+			threshold:    failureThreshold,
+			openDuration: openDuration,
+		}
+	}
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type circuitBreaker struct {
+	mu           sync.Mutex
+	threshold    int
+	openDuration time.Duration
+	state        breakerState
+	failures     int
+	openedAt     time.Time
+	probing      bool
+}
+
+// allow reports whether a request may be sent. In the half-open state only
+// one trial request is allowed at a time.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.openDuration {
+			return false
+		}
+		b.state = breakerHalfOpen
+		fallthrough
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+	}
+	return true
+}
+
+// record updates the breaker with the outcome of a request
+func (b *circuitBreaker) record(resp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.probing = false
+	} else if b.state == breakerOpen {
+		// Outcome of a request sent before the circuit opened
+		return
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		// Cancelled by the caller; says nothing about the server
+	case isBreakerFailure(resp, err):
+		b.failures++
+		if b.state == breakerHalfOpen || b.failures >= b.threshold {
+			b.state = breakerOpen
+			b.openedAt = time.Now()
+		}
+	default:
+		b.failures = 0
+		b.state = breakerClosed
+	}
+}
+
+// isBreakerFailure reports whether an outcome indicates an unhealthy server
+func isBreakerFailure(resp *http.Response, err error) bool {
+	var httpErr *HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.StatusCode >= 500
+	case err != nil:
+		return true
+	default:
+		return resp.StatusCode >= 500
+	}
+}
@@ -0,0 +1,99 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if healthy.Load() {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithCircuitBreaker(2, 50*time.Millisecond))
+	ctx := context.Background()
+
+	// Two consecutive failures open the circuit
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(ctx, "/test", nil); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Request %d: circuit opened too early", i)
+		}
+	}
+	if _, err := client.Get(ctx, "/test", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 requests to reach the server, got %d", calls.Load())
+	}
+
+	// After the cooldown a failed trial request opens it again
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.Get(ctx, "/test", nil); errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("Expected trial request in half-open state")
+	}
+	if _, err := client.Get(ctx, "/test", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected circuit to reopen, got %v", err)
+	}
+
+	// A successful trial request closes it
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(ctx, "/test", nil)
+		if err != nil {
+			t.Fatalf("Request %d after recovery failed: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithCircuitBreaker(1, time.Minute))
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get(context.Background(), "/test", nil); errors.Is(err, ErrCircuitOpen) {
+			t.Fatal("Expected 4xx responses not to open the circuit")
+		}
+	}
+}
+
+func TestCircuitBreakerHalfOpenSingleTrial(t *testing.T) {
+	b := &circuitBreaker{threshold: 1, openDuration: time.Millisecond}
+	b.record(nil, errors.New("connection refused"))
+	time.Sleep(2 * time.Millisecond)
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if b.allow() {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed.Load() != 1 {
+		t.Errorf("Expected exactly 1 trial request, got %d", allowed.Load())
+	}
+}
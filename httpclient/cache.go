@@ -0,0 +1,84 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// Cache stores response bodies by key so they can be shared between
+// requests and, with a shared backend such as Redis, between instances.
+// Get reports whether key was found; a miss is not an error.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+}
+
+// WithCache serves GET requests without a body from cache, keyed by method
+// and URL, and stores 200 responses in it. Expiry is up to the cache, e.g.
+// the ttl of redis.Client.AsHTTPCache. Cache errors are treated as misses so
+// an unavailable cache never fails a request.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// cacheKey returns the cache key for a request, or "" if it isn't cacheable
+func (c *Client) cacheKey(method, endpoint string, body interface{}) string {
+	if c.cache == nil || method != http.MethodGet || body != nil {
+		return ""
+	}
+	return method + " " + c.BaseURL + endpoint
+}
+
+// cachedResponse returns the response stored under key, if any
+func (c *Client) cachedResponse(ctx context.Context, key string) (*http.Response, bool) {
+	entry, found, err := c.cache.Get(ctx, key)
+	if err != nil || !found {
+		return nil, false
+	}
+
+	contentType, body, ok := bytes.Cut(entry, []byte("\n"))
+	if !ok {
+		return nil, false
+	}
+
+	header := make(http.Header)
+	if len(contentType) > 0 {
+		header.Set("Content-Type", string(contentType))
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}, true
+}
+
+// storeResponse reads the body of a 200 resp into the cache under key and
+// replaces it with an in-memory copy
+func (c *Client) storeResponse(ctx context.Context, key string, resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// The content type is kept so typed helpers such as GetJSON work on hits
+	entry := append([]byte(resp.Header.Get("Content-Type")+"\n"), body...)
+	_ = c.cache.Set(ctx, key, entry)
+	return nil
+}
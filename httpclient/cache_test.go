@@ -0,0 +1,129 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	err     error
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{entries: make(map[string][]byte)}
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return nil, false, m.err
+	}
+	value, ok := m.entries[key]
+	return value, ok, nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.entries[key] = value
+	return nil
+}
+
+func TestWithCacheServesRepeatedGets(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1,"name":"Ada"}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithCache(newMemoryCache()))
+
+	for i := 0; i < 3; i++ {
+		user, err := GetJSON[struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}](context.Background(), client, "/users/1")
+		if err != nil {
+			t.Fatalf("GetJSON failed: %v", err)
+		}
+		if user.ID != 1 || user.Name != "Ada" {
+			t.Errorf("Unexpected user %+v", user)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected 1 request to reach the server, got %d", calls)
+	}
+}
+
+func TestWithCacheSkipsOtherRequests(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	cache := newMemoryCache()
+	client := New(server.URL, WithCache(cache), WithoutStatusErrors())
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		for _, send := range []func() (*http.Response, error){
+			func() (*http.Response, error) { return client.Post(ctx, "/users", nil) },
+			func() (*http.Response, error) { return client.Get(ctx, "/missing", nil) },
+		} {
+			resp, err := send()
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			resp.Body.Close()
+		}
+	}
+
+	if calls != 4 {
+		t.Errorf("Expected every request to reach the server, got %d", calls)
+	}
+	if len(cache.entries) != 0 {
+		t.Errorf("Expected nothing to be cached, got %v", cache.entries)
+	}
+}
+
+func TestWithCacheErrorIsAMiss(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("fresh"))
+	}))
+	defer server.Close()
+
+	cache := newMemoryCache()
+	cache.err = errors.New("cache unavailable")
+	client := New(server.URL, WithCache(cache))
+
+	resp, err := client.Get(context.Background(), "/data", nil)
+	if err != nil {
+		t.Fatalf("Expected the request to succeed despite the cache error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "fresh" {
+		t.Errorf("Expected body 'fresh', got %q", body)
+	}
+}
@@ -0,0 +1,35 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrResponseTooLarge indicates a response body exceeded the allowed size
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// DecodeResponse decodes the JSON body of resp into T, reading at most
+// maxBytes and rejecting unknown fields. The body is always closed.
+func DecodeResponse[T any](resp *http.Response, maxBytes int64) (T, error) {
+	var result T
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return result, err
+	}
+	if int64(len(data)) > maxBytes {
+		return result, fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, maxBytes)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return result, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result, nil
+}
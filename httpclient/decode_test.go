@@ -0,0 +1,68 @@
+package httpclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type decodeUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type trackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func newResponse(body string) (*http.Response, *trackingBody) {
+	tb := &trackingBody{Reader: strings.NewReader(body)}
+	return &http.Response{StatusCode: http.StatusOK, Body: tb}, tb
+}
+
+func TestDecodeResponse(t *testing.T) {
+	resp, body := newResponse(`{"id":1,"name":"John"}`)
+
+	user, err := DecodeResponse[decodeUser](resp, 1024)
+	if err != nil {
+		t.Fatalf("DecodeResponse failed: %v", err)
+	}
+	if user.ID != 1 || user.Name != "John" {
+		t.Errorf("Unexpected result: %+v", user)
+	}
+	if !body.closed {
+		t.Error("Expected body to be closed")
+	}
+}
+
+func TestDecodeResponseTooLarge(t *testing.T) {
+	resp, body := newResponse(`{"id":1,"name":"` + strings.Repeat("x", 100) + `"}`)
+
+	_, err := DecodeResponse[decodeUser](resp, 32)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("Expected ErrResponseTooLarge, got %v", err)
+	}
+	if !body.closed {
+		t.Error("Expected body to be closed")
+	}
+}
+
+func TestDecodeResponseUnknownField(t *testing.T) {
+	resp, body := newResponse(`{"id":1,"name":"John","admin":true}`)
+
+	_, err := DecodeResponse[decodeUser](resp, 1024)
+	if err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Errorf("Expected unknown field error, got %v", err)
+	}
+	if !body.closed {
+		t.Error("Expected body to be closed")
+	}
+}
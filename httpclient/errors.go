@@ -0,0 +1,65 @@
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPError describes a response with an unexpected status code
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	URL        string
+	Body       []byte
+
+	// BodyErr is the error that stopped reading Body, e.g. one wrapping
+	// ErrResponseTooLarge, in which case Body holds only what was read
+	BodyErr error
+}
+
+// Error includes the response body so logs keep the server's message
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("unexpected status %s from %s", e.Status, e.URL)
+	if len(e.Body) > 0 {
+		msg += ": " + string(e.Body)
+	}
+	if e.BodyErr != nil {
+		msg += fmt.Sprintf(" (reading body: %v)", e.BodyErr)
+	}
+	return msg
+}
+
+// Unwrap returns BodyErr so errors.Is can match ErrResponseTooLarge
+func (e *HTTPError) Unwrap() error {
+	return e.BodyErr
+}
+
+// newHTTPError reads and closes the body of resp and wraps it in an HTTPError
+func newHTTPError(resp *http.Response) *HTTPError {
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+
+	httpErr := &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       data,
+		BodyErr:    err,
+	}
+	if httpErr.Status == "" {
+		httpErr.Status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		httpErr.URL = resp.Request.URL.String()
+	}
+	return httpErr
+}
+
+// ExpectStatus returns an *HTTPError carrying the body when resp does not have
+// status want, closing the body. On a match the body is left open.
+func ExpectStatus(resp *http.Response, want int) error {
+	if resp.StatusCode != want {
+		return newHTTPError(resp)
+	}
+	return nil
+}
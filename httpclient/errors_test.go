@@ -0,0 +1,42 @@
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestExpectStatusMatch(t *testing.T) {
+	resp, body := newResponse(`{"id":1}`)
+
+	if err := ExpectStatus(resp, http.StatusOK); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if body.closed {
+		t.Error("Expected body to be left open on match")
+	}
+}
+
+func TestExpectStatusMismatch(t *testing.T) {
+	resp, body := newResponse("not found")
+	resp.StatusCode = http.StatusNotFound
+
+	err := ExpectStatus(resp, http.StatusOK)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected *HTTPError, got %v", err)
+	}
+	if httpErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", httpErr.StatusCode)
+	}
+	if httpErr.Status != "404 Not Found" {
+		t.Errorf("Expected status text '404 Not Found', got %q", httpErr.Status)
+	}
+	if string(httpErr.Body) != "not found" {
+		t.Errorf("Expected body 'not found', got %q", httpErr.Body)
+	}
+	if !body.closed {
+		t.Error("Expected body to be closed on mismatch")
+	}
+}
@@ -0,0 +1,125 @@
+package httpclient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes a completed request attempt
+type RequestInfo struct {
+	Method     string
+	URL        string
+	StatusCode int // 0 when no response was received
+	Duration   time.Duration
+	Err        error
+
+	// RequestBytes is the size of the request body, or -1 for streamed
+	// bodies. ResponseBytes is the response Content-Length, or the exact
+	// size when bodies are logged; -1 when unknown.
+	RequestBytes  int64
+	ResponseBytes int64
+
+	// RequestBody and ResponseBody are only set with WithBodyLogging.
+	// ResponseBody holds at most the first 64 KiB of the body.
+	RequestBody  []byte
+	ResponseBody []byte
+}
+
+// maxLoggedBodyBytes caps how much of a response body WithBodyLogging reads
+// ahead, so large or streamed responses are not buffered in memory
+const maxLoggedBodyBytes = 64 << 10
+
+// WithLogger calls fn after every request attempt completes, including
+// failed and retried attempts. Bodies are not included unless
+// WithBodyLogging is also used.
+func WithLogger(fn func(info RequestInfo)) Option {
+	return func(c *Client) {
+		c.logFn = fn
+	}
+}
+
+// WithBodyLogging includes request and response bodies in RequestInfo.
+// Bodies may contain credentials or personal data, so only enable it when
+// the logger output is trusted.
+func WithBodyLogging() Option {
+	return func(c *Client) {
+		c.logBodies = true
+	}
+}
+
+// logRequest reports a request attempt to the configured logger. Stream
+// bodies are never read ahead, since that would block until the stream sends
+// enough data.
+func (c *Client) logRequest(req *http.Request, payload []byte, start time.Time, resp *http.Response, err error, rc *requestConfig) {
+	if c.logFn == nil {
+		return
+	}
+
+	info := RequestInfo{
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		Duration:      time.Since(start),
+		Err:           err,
+		RequestBytes:  req.ContentLength,
+		ResponseBytes: -1,
+	}
+	if req.ContentLength == 0 && req.Body != nil && req.Body != http.NoBody {
+		info.RequestBytes = -1
+	}
+	if c.logBodies {
+		info.RequestBody = payload
+	}
+
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+		info.ResponseBytes = resp.ContentLength
+
+		if c.logBodies && !rc.stream {
+			var complete bool
+			info.ResponseBody, complete = peekBody(resp, maxLoggedBodyBytes)
+			if complete {
+				info.ResponseBytes = int64(len(info.ResponseBody))
+			}
+		}
+	}
+
+	c.logFn(info)
+}
+
+// peekBody reads up to limit bytes of the body of resp for logging and
+// replaces the body so the caller still reads all of it, including any read
+// error. It reports whether the whole body was read.
+func peekBody(resp *http.Response, limit int64) ([]byte, bool) {
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+
+	var rest io.Reader = resp.Body
+	if err != nil {
+		rest = errReader{err}
+	}
+	resp.Body = &peekedBody{
+		Reader: io.MultiReader(bytes.NewReader(data), rest),
+		body:   resp.Body,
+	}
+	return data, err == nil && int64(len(data)) < limit
+}
+
+// peekedBody replays the bytes read by peekBody before the rest of the body
+type peekedBody struct {
+	io.Reader
+	body io.Closer
+}
+
+func (b *peekedBody) Close() error {
+	return b.body.Close()
+}
+
+// errReader fails every read with err
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
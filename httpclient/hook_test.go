@@ -0,0 +1,159 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	var infos []RequestInfo
+	client := New(server.URL, WithLogger(func(info RequestInfo) {
+		infos = append(infos, info)
+	}))
+
+	resp, err := client.Post(context.Background(), "/users", map[string]string{"name": "John"})
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(infos) != 1 {
+		t.Fatalf("Expected 1 log call, got %d", len(infos))
+	}
+	info := infos[0]
+	if info.Method != http.MethodPost {
+		t.Errorf("Expected method POST, got %s", info.Method)
+	}
+	if info.URL != server.URL+"/users" {
+		t.Errorf("Expected URL %s/users, got %s", server.URL, info.URL)
+	}
+	if info.StatusCode != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d", info.StatusCode)
+	}
+	if info.RequestBytes != int64(len(`{"name":"John"}`)) {
+		t.Errorf("Unexpected request bytes: %d", info.RequestBytes)
+	}
+	if info.ResponseBytes != int64(len(`{"id":1}`)) {
+		t.Errorf("Unexpected response bytes: %d", info.ResponseBytes)
+	}
+	if info.Duration <= 0 {
+		t.Error("Expected a positive duration")
+	}
+	if info.RequestBody != nil || info.ResponseBody != nil {
+		t.Error("Expected bodies not to be logged by default")
+	}
+}
+
+func TestWithBodyLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	var logged RequestInfo
+	client := New(server.URL, WithLogger(func(info RequestInfo) {
+		logged = info
+	}), WithBodyLogging())
+
+	resp, err := client.Post(context.Background(), "/users", map[string]string{"name": "John"})
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if string(logged.RequestBody) != `{"name":"John"}` {
+		t.Errorf("Unexpected request body: %s", logged.RequestBody)
+	}
+	if string(logged.ResponseBody) != `{"id":1}` {
+		t.Errorf("Unexpected response body: %s", logged.ResponseBody)
+	}
+
+	// The caller can still read the body
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"id":1}` {
+		t.Errorf("Expected body to remain readable, got %s", body)
+	}
+}
+
+func TestWithBodyLoggingLargeBody(t *testing.T) {
+	large := strings.Repeat("x", 3*maxLoggedBodyBytes)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(large))
+	}))
+	defer server.Close()
+
+	var logged RequestInfo
+	client := New(server.URL, WithLogger(func(info RequestInfo) {
+		logged = info
+	}), WithBodyLogging())
+
+	resp, err := client.Get(context.Background(), "/large", nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if len(logged.ResponseBody) != maxLoggedBodyBytes {
+		t.Errorf("Expected logged body capped at %d bytes, got %d", maxLoggedBodyBytes, len(logged.ResponseBody))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	if string(body) != large {
+		t.Errorf("Expected the full %d-byte body, got %d bytes", len(large), len(body))
+	}
+}
+
+func TestWithBodyLoggingReadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Promise more than is sent, then drop the connection
+		w.Header().Set("Content-Length", "100")
+		w.Write([]byte("partial"))
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithLogger(func(RequestInfo) {}), WithBodyLogging())
+
+	resp, err := client.Get(context.Background(), "/truncated", nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.ReadAll(resp.Body); err == nil {
+		t.Error("Expected the truncated body to fail to read")
+	}
+}
+
+func TestWithLoggerFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	var logged RequestInfo
+	client := New(server.URL, WithLogger(func(info RequestInfo) {
+		logged = info
+	}))
+
+	if _, err := client.Get(context.Background(), "/test", nil); err == nil {
+		t.Fatal("Expected error for closed server")
+	}
+	if logged.Err == nil {
+		t.Error("Expected failure to be logged")
+	}
+	if logged.StatusCode != 0 {
+		t.Errorf("Expected status 0, got %d", logged.StatusCode)
+	}
+}
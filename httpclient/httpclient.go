@@ -4,23 +4,55 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	Headers    map[string]string
+
+	retry            retryConfig
+	maxResponseBytes int64
+	maxLineBytes     int
+	noStatusErrors   bool
+	acceptStatuses   []int
+	logFn            func(RequestInfo)
+	logBodies        bool
+	timeout          time.Duration
+	transport        http.RoundTripper
+	tracerName       string
+	breaker          *circuitBreaker
+	cache            Cache
 }
 
 type Option func(*Client)
 
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Client) {
-		c.HTTPClient.Timeout = timeout
+		c.timeout = timeout
+	}
+}
+
+// WithHTTPClient uses client for requests, e.g. one with a tuned transport.
+// WithTimeout and WithTransport are applied to it regardless of option order.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = client
+	}
+}
+
+// WithTransport sets the RoundTripper used for requests, for proxies, mTLS
+// client certificates or connection pool tuning
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = transport
 	}
 }
 
@@ -44,24 +76,85 @@ func New(baseURL string, opts ...Option) *Client {
 	for _, opt := range opts {
 		opt(c)
 	}
+
+	// Copy the client before overriding it so a client passed to
+	// WithHTTPClient, e.g. http.DefaultClient, is never modified
+	if c.timeout > 0 || c.transport != nil {
+		httpClient := *c.HTTPClient
+		c.HTTPClient = &httpClient
+	}
+	if c.timeout > 0 {
+		c.HTTPClient.Timeout = c.timeout
+	}
+	if c.transport != nil {
+		c.HTTPClient.Transport = c.transport
+	}
 	return c
 }
 
-func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}, contentType string) (*http.Response, error) {
-	var bodyReader io.Reader
+func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}, contentType string, opts ...RequestOption) (*http.Response, error) {
+	rc := newRequestConfig(opts)
+
+	var key string
+	if !rc.stream {
+		key = c.cacheKey(method, endpoint, body)
+	}
+	if key != "" {
+		if resp, ok := c.cachedResponse(ctx, key); ok {
+			return resp, nil
+		}
+	}
+
+	if c.breaker != nil && !c.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	var span trace.Span
+	if c.tracerName != "" {
+		ctx, span = c.startSpan(ctx, method, c.BaseURL+endpoint)
+		defer span.End()
+	}
+
+	resp, err := c.send(ctx, method, endpoint, body, contentType, rc)
+
+	if span != nil {
+		finishSpan(span, resp, err)
+	}
+	if c.breaker != nil {
+		c.breaker.record(resp, err)
+	}
+	if err == nil && key != "" {
+		if err := c.storeResponse(ctx, key, resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp, err
+}
+
+func (c *Client) send(ctx context.Context, method, endpoint string, body interface{}, contentType string, rc *requestConfig) (*http.Response, error) {
+	attempts := c.retry.attempts(method)
+	var payload []byte
+	var stream io.Reader
 
 	switch v := body.(type) {
 	case string:
-		bodyReader = bytes.NewBufferString(v)
+		payload = []byte(v)
 	case []byte:
-		bodyReader = bytes.NewBuffer(v)
+		payload = v
+	case url.Values:
+		payload = []byte(v.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	case io.Reader:
+		// Streamed bodies can only be sent once
+		stream = v
+		attempts = 1
 	case nil:
 	default:
 		jsonData, err := json.Marshal(v)
 		if err != nil {
 			return nil, err
 		}
-		bodyReader = bytes.NewBuffer(jsonData)
+		payload = jsonData
 		if contentType == "" {
 			contentType = "application/json"
 		}
@@ -69,6 +162,41 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 
 	fullURL := c.BaseURL + endpoint
 
+	var resp *http.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		bodyReader := stream
+		if stream == nil && body != nil {
+			bodyReader = bytes.NewReader(payload)
+		}
+
+		resp, err = c.do(ctx, method, fullURL, bodyReader, payload, contentType, rc)
+		if attempt >= attempts || !c.retry.shouldRetry(ctx, resp, err) {
+			break
+		}
+
+		delay := c.retry.delay(attempt, resp)
+		if !wait(ctx, delay) {
+			break
+		}
+		if resp != nil {
+			discard(resp)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if c.maxResponseBytes > 0 && !rc.stream {
+		resp.Body = newLimitedBody(resp.Body, c.maxResponseBytes)
+	}
+	if c.isStatusError(resp.StatusCode) {
+		return nil, newHTTPError(resp)
+	}
+	return resp, nil
+}
+
+func (c *Client) do(ctx context.Context, method, fullURL string, bodyReader io.Reader, payload []byte, contentType string, rc *requestConfig) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
 		return nil, err
@@ -77,43 +205,92 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 	for k, v := range c.Headers {
 		req.Header.Set(k, v)
 	}
+	for k, v := range rc.headers {
+		req.Header.Set(k, v)
+	}
 	if bodyReader != nil && contentType != "" {
 		req.Header.Set("Content-Type", contentType)
 	}
+	if c.tracerName != "" {
+		traceContext.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
 
-	resp, err := c.HTTPClient.Do(req)
+	httpClient := c.HTTPClient
+	if rc.stream && httpClient.Timeout != 0 {
+		streamClient := *httpClient
+		streamClient.Timeout = 0
+		httpClient = &streamClient
+	}
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	c.logRequest(req, payload, start, resp, err, rc)
+	return resp, err
+}
+
+func (c *Client) Get(ctx context.Context, endpoint string, body interface{}, opts ...RequestOption) (*http.Response, error) {
+	return c.makeRequest(ctx, http.MethodGet, endpoint, body, "application/json", opts...)
+}
+
+// GetWithParams performs a GET request with params URL-encoded and merged
+// into any query string already present in endpoint
+func (c *Client) GetWithParams(ctx context.Context, endpoint string, params url.Values, body interface{}, opts ...RequestOption) (*http.Response, error) {
+	endpoint, err := appendQuery(endpoint, params)
 	if err != nil {
 		return nil, err
 	}
+	return c.Get(ctx, endpoint, body, opts...)
+}
 
-	if resp.StatusCode >= 400 {
-		defer resp.Body.Close()
-		data, _ := io.ReadAll(resp.Body)
-		return nil, errors.New(string(data))
+// appendQuery adds params to the query string of endpoint, keeping any
+// existing parameters as they are
+func appendQuery(endpoint string, params url.Values) (string, error) {
+	if len(params) == 0 {
+		return endpoint, nil
 	}
-	return resp, nil
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if u.RawQuery == "" {
+		u.RawQuery = params.Encode()
+	} else {
+		u.RawQuery += "&" + params.Encode()
+	}
+	return u.String(), nil
+}
+
+func (c *Client) Post(ctx context.Context, endpoint string, body interface{}, opts ...RequestOption) (*http.Response, error) {
+	return c.makeRequest(ctx, http.MethodPost, endpoint, body, "application/json", opts...)
+}
+
+func (c *Client) PostRaw(ctx context.Context, endpoint string, rawBody string, contentType string, opts ...RequestOption) (*http.Response, error) {
+	return c.makeRequest(ctx, http.MethodPost, endpoint, rawBody, contentType, opts...)
 }
 
-func (c *Client) Get(ctx context.Context, endpoint string, body interface{}) (*http.Response, error) {
-	return c.makeRequest(ctx, http.MethodGet, endpoint, body, "application/json")
+// PostForm performs a POST request with values encoded as
+// application/x-www-form-urlencoded. Repeated keys are sent once per value.
+func (c *Client) PostForm(ctx context.Context, endpoint string, values url.Values, opts ...RequestOption) (*http.Response, error) {
+	return c.makeRequest(ctx, http.MethodPost, endpoint, values, "", opts...)
 }
 
-func (c *Client) Post(ctx context.Context, endpoint string, body interface{}) (*http.Response, error) {
-	return c.makeRequest(ctx, http.MethodPost, endpoint, body, "application/json")
+func (c *Client) Put(ctx context.Context, endpoint string, body interface{}, opts ...RequestOption) (*http.Response, error) {
+	return c.makeRequest(ctx, http.MethodPut, endpoint, body, "application/json", opts...)
 }
 
-func (c *Client) PostRaw(ctx context.Context, endpoint string, rawBody string, contentType string) (*http.Response, error) {
-	return c.makeRequest(ctx, http.MethodPost, endpoint, rawBody, contentType)
+func (c *Client) PutRaw(ctx context.Context, endpoint string, rawBody string, contentType string, opts ...RequestOption) (*http.Response, error) {
+	return c.makeRequest(ctx, http.MethodPut, endpoint, rawBody, contentType, opts...)
 }
 
-func (c *Client) Put(ctx context.Context, endpoint string, body interface{}) (*http.Response, error) {
-	return c.makeRequest(ctx, http.MethodPut, endpoint, body, "application/json")
+func (c *Client) Patch(ctx context.Context, endpoint string, body interface{}, opts ...RequestOption) (*http.Response, error) {
+	return c.makeRequest(ctx, http.MethodPatch, endpoint, body, "application/json", opts...)
 }
 
-func (c *Client) PutRaw(ctx context.Context, endpoint string, rawBody string, contentType string) (*http.Response, error) {
-	return c.makeRequest(ctx, http.MethodPut, endpoint, rawBody, contentType)
+func (c *Client) PatchRaw(ctx context.Context, endpoint string, rawBody string, contentType string, opts ...RequestOption) (*http.Response, error) {
+	return c.makeRequest(ctx, http.MethodPatch, endpoint, rawBody, contentType, opts...)
 }
 
-func (c *Client) Delete(ctx context.Context, endpoint string, body interface{}) (*http.Response, error) {
-	return c.makeRequest(ctx, http.MethodDelete, endpoint, body, "application/json")
+func (c *Client) Delete(ctx context.Context, endpoint string, body interface{}, opts ...RequestOption) (*http.Response, error) {
+	return c.makeRequest(ctx, http.MethodDelete, endpoint, body, "application/json", opts...)
 }
@@ -2,13 +2,107 @@ package httpclient
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
+func TestGetWithParams(t *testing.T) {
+	tests := []struct {
+		name          string
+		endpoint      string
+		params        url.Values
+		expectedQuery string
+	}{
+		{
+			name:          "special characters",
+			endpoint:      "/search",
+			params:        url.Values{"q": {"go & redis"}, "tag": {"a b"}},
+			expectedQuery: "q=go+%26+redis&tag=a+b",
+		},
+		{
+			name:          "merge with existing query",
+			endpoint:      "/search?page=2",
+			params:        url.Values{"q": {"x=y"}},
+			expectedQuery: "page=2&q=x%3Dy",
+		},
+		{
+			name:          "repeated keys",
+			endpoint:      "/search",
+			params:        url.Values{"id": {"1", "2"}},
+			expectedQuery: "id=1&id=2",
+		},
+		{
+			name:          "no params",
+			endpoint:      "/search?page=1",
+			params:        nil,
+			expectedQuery: "page=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/search" {
+					t.Errorf("Expected path /search, got %s", r.URL.Path)
+				}
+				if r.URL.RawQuery != tt.expectedQuery {
+					t.Errorf("Expected query %s, got %s", tt.expectedQuery, r.URL.RawQuery)
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := New(server.URL)
+			resp, err := client.GetWithParams(context.Background(), tt.endpoint, tt.params, nil)
+			if err != nil {
+				t.Fatalf("GetWithParams failed: %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestPostForm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected method POST, got %s", r.Method)
+		}
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Errorf("Expected Content-Type application/x-www-form-urlencoded, got %s", r.Header.Get("Content-Type"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expectedBody := "name=John+Doe&tags=a&tags=b&user%5Baddress%5D%5Bcity%5D=NYC"
+		if string(body) != expectedBody {
+			t.Errorf("Expected body %s, got %s", expectedBody, string(body))
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	ctx := context.Background()
+
+	values := url.Values{}
+	values.Set("name", "John Doe")
+	values.Add("tags", "a")
+	values.Add("tags", "b")
+	values.Set("user[address][city]", "NYC")
+
+	resp, err := client.PostForm(ctx, "/login", values)
+	if err != nil {
+		t.Fatalf("PostForm failed: %v", err)
+	}
+	defer resp.Body.Close()
+}
+
 func TestPut(t *testing.T) {
 	type testData struct {
 		Name  string `json:"name"`
@@ -108,6 +202,78 @@ func TestPutWithNilBody(t *testing.T) {
 	}
 }
 
+func TestPatch(t *testing.T) {
+	type testData struct {
+		Name string `json:"name"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected method PATCH, got %s", r.Method)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected Content-Type application/json, got %s", r.Header.Get("Content-Type"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expectedBody := `{"name":"patched"}`
+		if string(body) != expectedBody {
+			t.Errorf("Expected body %s, got %s", expectedBody, string(body))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	ctx := context.Background()
+
+	resp, err := client.Patch(ctx, "/test", testData{Name: "patched"})
+	if err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestPatchRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected method PATCH, got %s", r.Method)
+		}
+		if r.Header.Get("Content-Type") != "application/merge-patch+json" {
+			t.Errorf("Expected Content-Type application/merge-patch+json, got %s", r.Header.Get("Content-Type"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expectedBody := `{"name":null}`
+		if string(body) != expectedBody {
+			t.Errorf("Expected body %s, got %s", expectedBody, string(body))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	ctx := context.Background()
+
+	resp, err := client.PatchRaw(ctx, "/test", `{"name":null}`, "application/merge-patch+json")
+	if err != nil {
+		t.Fatalf("PatchRaw failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	type deleteData struct {
 		Reason string `json:"reason"`
@@ -202,6 +368,39 @@ func TestDeleteError(t *testing.T) {
 	}
 }
 
+func TestHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error":"already exists"}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	ctx := context.Background()
+
+	_, err := client.Post(ctx, "/users", map[string]string{"name": "John"})
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected *HTTPError, got %T: %v", err, err)
+	}
+	if httpErr.StatusCode != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", httpErr.StatusCode)
+	}
+	if httpErr.Status != "409 Conflict" {
+		t.Errorf("Expected status '409 Conflict', got %q", httpErr.Status)
+	}
+	if httpErr.URL != server.URL+"/users" {
+		t.Errorf("Expected URL %s/users, got %s", server.URL, httpErr.URL)
+	}
+	if string(httpErr.Body) != `{"error":"already exists"}` {
+		t.Errorf("Unexpected body: %s", httpErr.Body)
+	}
+	if !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected error message to contain the body, got: %v", err)
+	}
+}
+
 func TestPutWithCustomHeaders(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header.Get("X-Custom-Header") != "custom-value" {
@@ -255,3 +454,73 @@ func TestDeleteWithCustomHeaders(t *testing.T) {
 		t.Errorf("Expected status 200, got %d", resp.StatusCode)
 	}
 }
+
+type recordingTransport struct {
+	calls int
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.calls++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := &recordingTransport{}
+	client := New(server.URL, WithTransport(transport))
+
+	resp, err := client.Get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if transport.calls != 1 {
+		t.Errorf("Expected custom transport to be used once, got %d", transport.calls)
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	transport := &recordingTransport{}
+	custom := &http.Client{Transport: transport}
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"timeout before client", []Option{WithTimeout(3 * time.Second), WithHTTPClient(custom)}},
+		{"timeout after client", []Option{WithHTTPClient(custom), WithTimeout(3 * time.Second)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := New("http://example.com", tt.opts...)
+
+			if client.HTTPClient.Timeout != 3*time.Second {
+				t.Errorf("Expected timeout 3s, got %s", client.HTTPClient.Timeout)
+			}
+			if client.HTTPClient.Transport != transport {
+				t.Error("Expected the supplied client's transport to be kept")
+			}
+			if custom.Timeout != 0 {
+				t.Errorf("Expected the supplied client to be left unchanged, got timeout %s", custom.Timeout)
+			}
+		})
+	}
+}
+
+func TestWithHTTPClientDoesNotModifyDefaultClient(t *testing.T) {
+	timeout := http.DefaultClient.Timeout
+	New("http://example.com", WithHTTPClient(http.DefaultClient), WithTimeout(time.Second), WithTransport(&recordingTransport{}))
+
+	if http.DefaultClient.Timeout != timeout {
+		t.Errorf("Expected http.DefaultClient.Timeout to stay %s, got %s", timeout, http.DefaultClient.Timeout)
+	}
+	if http.DefaultClient.Transport != nil {
+		t.Error("Expected http.DefaultClient.Transport to stay nil")
+	}
+}
@@ -0,0 +1,66 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotJSON indicates a response did not have a JSON content type
+	ErrNotJSON = errors.New("response is not JSON")
+
+	// ErrDecode indicates a response body could not be decoded
+	ErrDecode = errors.New("failed to decode response")
+)
+
+// GetJSON performs a GET request and decodes the JSON response into T
+func GetJSON[T any](ctx context.Context, c *Client, endpoint string, opts ...RequestOption) (T, error) {
+	resp, err := c.Get(ctx, endpoint, nil, opts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return decodeJSON[T](resp)
+}
+
+// PostJSON performs a POST request with a JSON body and decodes the JSON
+// response into T
+func PostJSON[T any](ctx context.Context, c *Client, endpoint string, body interface{}, opts ...RequestOption) (T, error) {
+	resp, err := c.Post(ctx, endpoint, body, opts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return decodeJSON[T](resp)
+}
+
+// decodeJSON decodes the body of resp into T and closes it. Errors wrap
+// ErrNotJSON or ErrDecode so they can be told apart from transport errors.
+func decodeJSON[T any](resp *http.Response) (T, error) {
+	var result T
+	defer resp.Body.Close()
+
+	contentType := resp.Header.Get("Content-Type")
+	if !isJSON(contentType) {
+		return result, fmt.Errorf("%w: content type %q", ErrNotJSON, contentType)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	return result, nil
+}
+
+// isJSON reports whether contentType is application/json or a +json type
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
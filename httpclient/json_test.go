@@ -0,0 +1,101 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected method GET, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"id":1,"name":"John"}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	user, err := GetJSON[decodeUser](context.Background(), client, "/users/1")
+	if err != nil {
+		t.Fatalf("GetJSON failed: %v", err)
+	}
+	if user.ID != 1 || user.Name != "John" {
+		t.Errorf("Unexpected result: %+v", user)
+	}
+}
+
+func TestPostJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected method POST, got %s", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"Jane"}` {
+			t.Errorf("Unexpected request body: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":2,"name":"Jane"}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	user, err := PostJSON[decodeUser](context.Background(), client, "/users", map[string]string{"name": "Jane"})
+	if err != nil {
+		t.Fatalf("PostJSON failed: %v", err)
+	}
+	if user.ID != 2 || user.Name != "Jane" {
+		t.Errorf("Unexpected result: %+v", user)
+	}
+}
+
+func TestGetJSONErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		expected    error
+	}{
+		{"not json", http.StatusOK, "text/html", "<html></html>", ErrNotJSON},
+		{"malformed json", http.StatusOK, "application/json", `{"id":`, ErrDecode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := GetJSON[decodeUser](context.Background(), New(server.URL), "/users/1")
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetJSONHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := GetJSON[decodeUser](context.Background(), New(server.URL), "/users/1")
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 HTTPError, got %v", err)
+	}
+	if errors.Is(err, ErrDecode) {
+		t.Error("Expected HTTP error not to be a decode error")
+	}
+}
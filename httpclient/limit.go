@@ -0,0 +1,45 @@
+package httpclient
+
+import (
+	"fmt"
+	"io"
+)
+
+// WithMaxResponseBytes caps how many bytes are read from a response body,
+// including error bodies. Reading past the cap fails with an error wrapping
+// ErrResponseTooLarge. A value of 0 (the default) means unlimited.
+func WithMaxResponseBytes(n int64) Option {
+	return func(c *Client) {
+		c.maxResponseBytes = n
+	}
+}
+
+// limitedBody is a response body that fails once more than limit bytes are read
+type limitedBody struct {
+	io.ReadCloser
+	limit     int64
+	remaining int64
+}
+
+func newLimitedBody(body io.ReadCloser, limit int64) *limitedBody {
+	return &limitedBody{ReadCloser: body, limit: limit, remaining: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// Probe for data beyond the limit to tell a full body from an oversized one
+		var probe [1]byte
+		n, err := b.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, b.limit)
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,78 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithMaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		limit   int64
+		wantErr bool
+	}{
+		{"under limit", 200, false},
+		{"exact limit", 100, false},
+		{"over limit", 10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := New(server.URL, WithMaxResponseBytes(tt.limit))
+			resp, err := client.Get(context.Background(), "/test", nil)
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			data, err := io.ReadAll(resp.Body)
+			if tt.wantErr {
+				if !errors.Is(err, ErrResponseTooLarge) {
+					t.Errorf("Expected ErrResponseTooLarge, got %v", err)
+				}
+				if int64(len(data)) != tt.limit {
+					t.Errorf("Expected %d bytes read, got %d", tt.limit, len(data))
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if len(data) != 100 {
+				t.Errorf("Expected 100 bytes, got %d", len(data))
+			}
+		})
+	}
+}
+
+func TestWithMaxResponseBytesErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(strings.Repeat("e", 1000)))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithMaxResponseBytes(16))
+	_, err := client.Get(context.Background(), "/test", nil)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected *HTTPError, got %v", err)
+	}
+	if len(httpErr.Body) != 16 {
+		t.Errorf("Expected error body capped at 16 bytes, got %d", len(httpErr.Body))
+	}
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("Expected the truncation to be reported as ErrResponseTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package httpclient
+
+// RequestOption configures a single request without changing the Client
+type RequestOption func(*requestConfig)
+
+type requestConfig struct {
+	headers map[string]string
+	stream  bool
+}
+
+func newRequestConfig(opts []RequestOption) *requestConfig {
+	rc := &requestConfig{}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	return rc
+}
+
+// Header sets a header for one request, overriding the client's headers.
+// The shared Client is never modified, so it is safe for concurrent use.
+func Header(key, value string) RequestOption {
+	return func(rc *requestConfig) {
+		if rc.headers == nil {
+			rc.headers = make(map[string]string)
+		}
+		rc.headers[key] = value
+	}
+}
+
+// asStream marks a request as a long-lived stream: the client timeout and
+// response size limit don't apply, and the response is neither cached nor
+// read ahead for body logging
+func asStream() RequestOption {
+	return func(rc *requestConfig) {
+		rc.stream = true
+	}
+}
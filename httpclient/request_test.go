@@ -0,0 +1,78 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestRequestHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer override" {
+			t.Errorf("Expected Authorization 'Bearer override', got %s", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("Idempotency-Key") != "abc123" {
+			t.Errorf("Expected Idempotency-Key abc123, got %s", r.Header.Get("Idempotency-Key"))
+		}
+		if r.Header.Get("User-Agent") != "test-agent" {
+			t.Errorf("Expected User-Agent test-agent, got %s", r.Header.Get("User-Agent"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithHeaders(map[string]string{
+		"Authorization": "Bearer default",
+		"User-Agent":    "test-agent",
+	}))
+
+	resp, err := client.Post(context.Background(), "/test", map[string]string{"a": "b"},
+		Header("Authorization", "Bearer override"),
+		Header("Idempotency-Key", "abc123"),
+	)
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if client.Headers["Authorization"] != "Bearer default" {
+		t.Errorf("Expected client headers to be unchanged, got %s", client.Headers["Authorization"])
+	}
+	if _, ok := client.Headers["Idempotency-Key"]; ok {
+		t.Error("Expected per-request header not to leak into client headers")
+	}
+}
+
+func TestRequestHeaderConcurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Request-ID")))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+
+			resp, err := client.Get(context.Background(), "/test", nil, Header("X-Request-ID", id))
+			if err != nil {
+				t.Errorf("Get failed: %v", err)
+				return
+			}
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != id {
+				t.Errorf("Expected X-Request-ID %s, got %s", id, body)
+			}
+		}(fmt.Sprintf("req-%d", i))
+	}
+	wg.Wait()
+}
@@ -0,0 +1,141 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRetryStatuses are the status codes retried unless overridden
+var defaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+type retryConfig struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	statuses    []int
+	retryPost   bool
+}
+
+// WithRetry retries idempotent requests (GET, PUT, DELETE) up to maxAttempts
+// times in total on network errors and on 429, 502, 503 and 504 responses,
+// with jittered exponential backoff starting at baseDelay. A Retry-After
+// header on 429 and 503 responses takes precedence over the backoff.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		c.retry.maxAttempts = maxAttempts
+		c.retry.baseDelay = baseDelay
+		if c.retry.statuses == nil {
+			c.retry.statuses = defaultRetryStatuses
+		}
+	}
+}
+
+// WithRetryStatuses replaces the status codes that are retried
+func WithRetryStatuses(codes ...int) Option {
+	return func(c *Client) {
+		c.retry.statuses = codes
+	}
+}
+
+// WithRetryPost also retries POST requests. Only use it when the server
+// handles duplicate POSTs safely, e.g. with idempotency keys.
+func WithRetryPost() Option {
+	return func(c *Client) {
+		c.retry.retryPost = true
+	}
+}
+
+// attempts returns how many times a request with method may be sent
+func (r retryConfig) attempts(method string) int {
+	if r.maxAttempts < 1 {
+		return 1
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return r.maxAttempts
+	case http.MethodPost:
+		if r.retryPost {
+			return r.maxAttempts
+		}
+	}
+	return 1
+}
+
+// shouldRetry reports whether a request that produced resp or err is worth retrying
+func (r retryConfig) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	for _, code := range r.statuses {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt
+func (r retryConfig) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	backoff := r.baseDelay << (attempt - 1)
+	if backoff <= 0 {
+		return 0
+	}
+	// Full jitter over the upper half of the window
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// wait sleeps for d, returning false if ctx is done first or its deadline
+// would pass before d elapses
+func wait(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// discard drains and closes a response body so the connection can be reused
+func discard(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
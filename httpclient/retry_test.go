@@ -0,0 +1,169 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newFlakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			w.Write([]byte("unavailable"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	server, calls := newFlakyServer(t, 2, http.StatusServiceUnavailable)
+
+	client := New(server.URL, WithRetry(3, time.Millisecond))
+	resp, err := client.Get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server, calls := newFlakyServer(t, 5, http.StatusBadGateway)
+
+	client := New(server.URL, WithRetry(2, time.Millisecond))
+	_, err := client.Delete(context.Background(), "/test", nil)
+	if err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestRetrySkipsNonRetryableStatus(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusInternalServerError)
+
+	client := New(server.URL, WithRetry(3, time.Millisecond))
+	if _, err := client.Get(context.Background(), "/test", nil); err == nil {
+		t.Fatal("Expected error for 500 response")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestRetryPostOptIn(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+
+	client := New(server.URL, WithRetry(3, time.Millisecond))
+	if _, err := client.Post(context.Background(), "/test", map[string]string{"a": "b"}); err == nil {
+		t.Fatal("Expected POST not to be retried by default")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 attempt, got %d", calls.Load())
+	}
+
+	calls.Store(0)
+	client = New(server.URL, WithRetry(3, time.Millisecond), WithRetryPost())
+	resp, err := client.Post(context.Background(), "/test", map[string]string{"a": "b"})
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestRetryCustomStatuses(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusInternalServerError)
+
+	client := New(server.URL, WithRetry(3, time.Millisecond), WithRetryStatuses(http.StatusInternalServerError))
+	resp, err := client.Get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestRetryStopsAtDeadline(t *testing.T) {
+	server, calls := newFlakyServer(t, 5, http.StatusServiceUnavailable)
+
+	client := New(server.URL, WithRetry(5, time.Second))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.Get(ctx, "/test", nil); err == nil {
+		t.Fatal("Expected error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected retries to stop at the deadline, took %s", elapsed)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// A long base delay would time out the test unless Retry-After is used
+	client := New(server.URL, WithRetry(2, time.Hour))
+	resp, err := client.Get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"invalid", 0, false},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		d, ok := parseRetryAfter(tt.value)
+		if d != tt.expected || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, d, ok, tt.expected, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package httpclient
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// defaultMaxLineBytes is the longest SSE line Stream accepts by default
+const defaultMaxLineBytes = 1 << 20
+
+// WithMaxEventLineBytes sets the longest line Stream accepts, such as a
+// single data line carrying a large JSON payload (default: 1 MiB). A longer
+// line ends the stream with an error wrapping bufio.ErrTooLong.
+func WithMaxEventLineBytes(n int) Option {
+	return func(c *Client) {
+		c.maxLineBytes = n
+	}
+}
+
+// Event is a single server-sent event
+type Event struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// Stream opens a server-sent events stream at endpoint and delivers parsed
+// events in order. Both channels are closed when ctx is cancelled or the
+// server ends the stream; any other failure is sent on the error channel
+// first. The request goes through the same path as Get, so headers, retries,
+// the circuit breaker, tracing and the logger apply, but the client timeout
+// and WithMaxResponseBytes do not: the stream stays open until ctx is done.
+func (c *Client) Stream(ctx context.Context, endpoint string, opts ...RequestOption) (<-chan Event, <-chan error) {
+	events := make(chan Event)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		if err := c.stream(ctx, endpoint, events, opts); err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+
+	return events, errs
+}
+
+func (c *Client) stream(ctx context.Context, endpoint string, events chan<- Event, opts []RequestOption) error {
+	opts = append([]RequestOption{
+		Header("Accept", "text/event-stream"),
+		Header("Cache-Control", "no-cache"),
+	}, opts...)
+	opts = append(opts, asStream())
+
+	resp, err := c.makeRequest(ctx, http.MethodGet, endpoint, nil, "", opts...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return newHTTPError(resp)
+	}
+
+	var event Event
+	var data []string
+
+	maxLineBytes := c.maxLineBytes
+	if maxLineBytes <= 0 {
+		maxLineBytes = defaultMaxLineBytes
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineBytes)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			event = Event{ID: event.ID}
+			data = nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			event.ID = value
+		}
+	}
+	err = scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		return fmt.Errorf("event stream line exceeds %d bytes: %w", maxLineBytes, err)
+	}
+	return err
+}
@@ -0,0 +1,217 @@
+package httpclient
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Expected Accept text/event-stream, got %s", r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "id: 1\nevent: created\ndata: {\"id\":1}\n\n")
+		flusher.Flush()
+		fmt.Fprint(w, "event: updated\ndata: line one\ndata: line two\n\n")
+		flusher.Flush()
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, errs := client.Stream(ctx, "/events")
+
+	var received []Event
+	for event := range events {
+		received = append(received, event)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+
+	expected := []Event{
+		{ID: "1", Event: "created", Data: `{"id":1}`},
+		{ID: "1", Event: "updated", Data: "line one\nline two"},
+	}
+	if len(received) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(expected), len(received), received)
+	}
+	for i, want := range expected {
+		if received[i] != want {
+			t.Errorf("Event %d: expected %+v, got %+v", i, want, received[i])
+		}
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: hello\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, errs := client.Stream(ctx, "/events")
+
+	if event := <-events; event.Data != "hello" {
+		t.Errorf("Expected data hello, got %q", event.Data)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("Expected events channel to be closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Events channel not closed after cancel")
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Expected no error on cancel, got %v", err)
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("Unauthorized"))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	events, errs := client.Stream(context.Background(), "/events")
+
+	for range events {
+		t.Error("Expected no events")
+	}
+	var httpErr *HTTPError
+	if err := <-errs; !errors.As(err, &httpErr) || string(httpErr.Body) != "Unauthorized" {
+		t.Errorf("Expected Unauthorized HTTPError, got %v", err)
+	}
+}
+
+func TestStreamLargeEvent(t *testing.T) {
+	large := strings.Repeat("x", 200*1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "data: %s\n\n", large)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("within default limit", func(t *testing.T) {
+		events, errs := New(server.URL).Stream(ctx, "/events")
+
+		var received []Event
+		for event := range events {
+			received = append(received, event)
+		}
+		if err := <-errs; err != nil {
+			t.Fatalf("Stream failed: %v", err)
+		}
+		if len(received) != 1 || received[0].Data != large {
+			t.Errorf("Expected one %d-byte event, got %d events", len(large), len(received))
+		}
+	})
+
+	t.Run("over configured limit", func(t *testing.T) {
+		events, errs := New(server.URL, WithMaxEventLineBytes(64*1024)).Stream(ctx, "/events")
+
+		for range events {
+			t.Error("Expected no events")
+		}
+		if err := <-errs; !errors.Is(err, bufio.ErrTooLong) {
+			t.Errorf("Expected bufio.ErrTooLong, got %v", err)
+		}
+	})
+}
+
+func TestStreamUsesClientOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Expected default Authorization header, got %q", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Request-ID") != "abc" {
+			t.Errorf("Expected per-request X-Request-ID header, got %q", r.Header.Get("X-Request-ID"))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		fmt.Fprint(w, "data: first\n\n")
+		flusher.Flush()
+
+		// Outlive the client timeout before sending the next event
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, "data: second\n\n")
+	}))
+	defer server.Close()
+
+	var logged []RequestInfo
+	client := New(server.URL,
+		WithHeaders(map[string]string{"Authorization": "Bearer token"}),
+		WithTimeout(50*time.Millisecond),
+		WithMaxResponseBytes(8),
+		WithLogger(func(info RequestInfo) { logged = append(logged, info) }),
+		WithBodyLogging(),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, errs := client.Stream(ctx, "/events", Header("X-Request-ID", "abc"))
+
+	var received []string
+	for event := range events {
+		received = append(received, event.Data)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	if len(received) != 2 {
+		t.Errorf("Expected 2 events despite the timeout and size limit, got %v", received)
+	}
+	if len(logged) != 1 || logged[0].StatusCode != http.StatusOK {
+		t.Errorf("Expected the stream request to be logged, got %+v", logged)
+	}
+	if len(logged) == 1 && logged[0].ResponseBody != nil {
+		t.Errorf("Expected the stream body not to be logged, got %q", logged[0].ResponseBody)
+	}
+}
+
+func TestStreamCircuitOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithCircuitBreaker(1, time.Minute))
+
+	_, errs := client.Stream(context.Background(), "/events")
+	if err := <-errs; err == nil {
+		t.Fatal("Expected the first stream to fail")
+	}
+
+	_, errs = client.Stream(context.Background(), "/events")
+	if err := <-errs; !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+}
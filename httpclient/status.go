@@ -0,0 +1,32 @@
+package httpclient
+
+// WithoutStatusErrors makes the client return 4xx and 5xx responses as-is
+// instead of converting them to an *HTTPError. The body is left open and
+// the caller must check resp.StatusCode and close it.
+func WithoutStatusErrors() Option {
+	return func(c *Client) {
+		c.noStatusErrors = true
+	}
+}
+
+// AcceptStatus makes the client return responses with the given 4xx or 5xx
+// codes as-is, like WithoutStatusErrors but only for those codes. Other
+// error statuses still return an *HTTPError.
+func AcceptStatus(codes ...int) Option {
+	return func(c *Client) {
+		c.acceptStatuses = append(c.acceptStatuses, codes...)
+	}
+}
+
+// isStatusError reports whether a response with code should become an *HTTPError
+func (c *Client) isStatusError(code int) bool {
+	if code < 400 || c.noStatusErrors {
+		return false
+	}
+	for _, accepted := range c.acceptStatuses {
+		if code == accepted {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newStatusServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not found"))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("boom"))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithoutStatusErrors(t *testing.T) {
+	server := newStatusServer(t)
+	client := New(server.URL, WithoutStatusErrors())
+
+	for _, tt := range []struct {
+		endpoint string
+		status   int
+		body     string
+	}{
+		{"/missing", http.StatusNotFound, "not found"},
+		{"/broken", http.StatusInternalServerError, "boom"},
+	} {
+		resp, err := client.Get(context.Background(), tt.endpoint, nil)
+		if err != nil {
+			t.Fatalf("Get %s failed: %v", tt.endpoint, err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("Expected body to be readable, got %v", err)
+		}
+		if resp.StatusCode != tt.status {
+			t.Errorf("Expected status %d, got %d", tt.status, resp.StatusCode)
+		}
+		if string(body) != tt.body {
+			t.Errorf("Expected body %q, got %q", tt.body, body)
+		}
+	}
+}
+
+func TestAcceptStatus(t *testing.T) {
+	server := newStatusServer(t)
+	client := New(server.URL, AcceptStatus(http.StatusNotFound))
+
+	resp, err := client.Get(context.Background(), "/missing", nil)
+	if err != nil {
+		t.Fatalf("Expected 404 to be accepted, got %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || string(body) != "not found" {
+		t.Errorf("Unexpected response: %d %q", resp.StatusCode, body)
+	}
+
+	_, err = client.Get(context.Background(), "/broken", nil)
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected 500 HTTPError, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceContext injects W3C traceparent and tracestate headers
+var traceContext = propagation.TraceContext{}
+
+// WithTracing starts a client span for every request using the tracer
+// tracerName from the global TracerProvider, and propagates the trace
+// context to the server through the W3C traceparent header
+func WithTracing(tracerName string) Option {
+	return func(c *Client) {
+		c.tracerName = tracerName
+	}
+}
+
+// startSpan starts a client span for a request to url
+func (c *Client) startSpan(ctx context.Context, method, url string) (context.Context, trace.Span) {
+	return otel.Tracer(c.tracerName).Start(ctx, "HTTP "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", method),
+			attribute.String("http.url", url),
+		),
+	)
+}
+
+// finishSpan records the outcome of a request on span
+func finishSpan(span trace.Span, resp *http.Response, err error) {
+	var httpErr *HTTPError
+	switch {
+	case resp != nil:
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	case errors.As(err, &httpErr):
+		span.SetAttributes(attribute.Int("http.status_code", httpErr.StatusCode))
+		span.SetStatus(codes.Error, httpErr.Status)
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
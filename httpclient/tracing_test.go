@@ -0,0 +1,107 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupTracing(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestWithTracing(t *testing.T) {
+	recorder := setupTracing(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithTracing("test"))
+	resp, err := client.Get(context.Background(), "/users/1", nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "HTTP GET" {
+		t.Errorf("Expected span name 'HTTP GET', got %s", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("Expected client span, got %s", span.SpanKind())
+	}
+
+	attrs := spanAttributes(span)
+	if attrs["http.method"].AsString() != http.MethodGet {
+		t.Errorf("Unexpected http.method: %v", attrs["http.method"])
+	}
+	if attrs["http.url"].AsString() != server.URL+"/users/1" {
+		t.Errorf("Unexpected http.url: %v", attrs["http.url"])
+	}
+	if attrs["http.status_code"].AsInt64() != http.StatusOK {
+		t.Errorf("Unexpected http.status_code: %v", attrs["http.status_code"])
+	}
+
+	expected := "00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"
+	if traceparent != expected {
+		t.Errorf("Expected traceparent %s, got %s", expected, traceparent)
+	}
+}
+
+func TestWithTracingError(t *testing.T) {
+	recorder := setupTracing(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithTracing("test"))
+	if _, err := client.Get(context.Background(), "/test", nil); err == nil {
+		t.Fatal("Expected error for 502 status")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Status().Code != codes.Error {
+		t.Errorf("Expected error status, got %v", span.Status())
+	}
+	if spanAttributes(span)["http.status_code"].AsInt64() != http.StatusBadGateway {
+		t.Errorf("Expected http.status_code 502")
+	}
+	if len(span.Events()) == 0 || span.Events()[0].Name != "exception" {
+		t.Error("Expected the error to be recorded")
+	}
+}
@@ -0,0 +1,79 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+)
+
+// FileUpload is a file sent as one part of a multipart upload
+type FileUpload struct {
+	FieldName   string
+	FileName    string
+	ContentType string // defaults to application/octet-stream
+	Reader      io.Reader
+}
+
+// Upload performs a multipart/form-data POST with fields and files. The body
+// is streamed as it is sent, so files are never buffered in memory; as a
+// result uploads are not retried.
+func (c *Client) Upload(ctx context.Context, endpoint string, fields map[string]string, files []FileUpload, opts ...RequestOption) (*http.Response, error) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeMultipart(writer, fields, files))
+	}()
+	// Unblocks the writer if the request ends before the body is consumed
+	defer pr.Close()
+
+	return c.makeRequest(ctx, http.MethodPost, endpoint, pr, writer.FormDataContentType(), opts...)
+}
+
+// writeMultipart writes fields in key order followed by files, then closes writer
+func writeMultipart(writer *multipart.Writer, fields map[string]string, files []FileUpload) error {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := writer.WriteField(key, fields[key]); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range files {
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			escapeQuotes(file.FieldName), escapeQuotes(file.FileName)))
+		header.Set("Content-Type", contentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, file.Reader); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", file.FileName, err)
+		}
+	}
+
+	return writer.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
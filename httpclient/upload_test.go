@@ -0,0 +1,97 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected method POST, got %s", r.Method)
+		}
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data; boundary=") {
+			t.Errorf("Expected multipart/form-data with boundary, got %s", r.Header.Get("Content-Type"))
+		}
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Fatalf("MultipartReader failed: %v", err)
+		}
+
+		type part struct {
+			name, fileName, contentType, body string
+		}
+		var parts []part
+		for {
+			p, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("NextPart failed: %v", err)
+			}
+			body, _ := io.ReadAll(p)
+			parts = append(parts, part{p.FormName(), p.FileName(), p.Header.Get("Content-Type"), string(body)})
+		}
+
+		expected := []part{
+			{"description", "", "", "monthly report"},
+			{"owner", "", "", "john"},
+			{"file", "report.csv", "text/csv", "a,b\n1,2\n"},
+			{"attachment", "data.bin", "application/octet-stream", "\x00\x01"},
+		}
+		if len(parts) != len(expected) {
+			t.Fatalf("Expected %d parts, got %d: %+v", len(expected), len(parts), parts)
+		}
+		for i, want := range expected {
+			if parts[i] != want {
+				t.Errorf("Part %d: expected %+v, got %+v", i, want, parts[i])
+			}
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	resp, err := client.Upload(context.Background(), "/upload",
+		map[string]string{"owner": "john", "description": "monthly report"},
+		[]FileUpload{
+			{FieldName: "file", FileName: "report.csv", ContentType: "text/csv", Reader: strings.NewReader("a,b\n1,2\n")},
+			{FieldName: "attachment", FileName: "data.bin", Reader: strings.NewReader("\x00\x01")},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d", resp.StatusCode)
+	}
+}
+
+func TestUploadNotRetried(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithRetry(3, 0), WithRetryPost())
+	_, err := client.Upload(context.Background(), "/upload", nil,
+		[]FileUpload{{FieldName: "file", FileName: "a.txt", Reader: strings.NewReader("data")}})
+	if err == nil {
+		t.Fatal("Expected error for 503 status")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 attempt, got %d", calls)
+	}
+}
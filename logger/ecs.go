@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+// errNotObject reports an event that is not a JSON object
+var errNotObject = errors.New("event is not a JSON object")
+
+// ecsVersion is the Elastic Common Schema version ECS output declares
+const ecsVersion = "1.6.0"
+
+// ECS field names for the fields the logger adds itself. Trace and span IDs
+// default to trace.id and span.id, and service and env are written as
+// service.name and service.environment.
+const (
+	ecsTimestampField   = "@timestamp"
+	ecsLevelField       = "log.level"
+	ecsErrorField       = "error.message"
+	ecsServiceField     = "service.name"
+	ecsEnvironmentField = "service.environment"
+	ecsTraceIDField     = "trace.id"
+	ecsSpanIDField      = "span.id"
+)
+
+// ecsWriter rewrites zerolog's JSON events into Elastic Common Schema
+// fields: time, level and error are renamed, @timestamp, log.level and
+// message come first, and ecs.version is added. Other fields are kept as
+// they are.
+type ecsWriter struct {
+	out io.Writer
+}
+
+func (w ecsWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w ecsWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	event, err := toECS(p)
+	if err != nil {
+		// Write events that aren't a JSON object unchanged rather than drop them
+		event = p
+	}
+
+	if lw, ok := w.out.(zerolog.LevelWriter); ok {
+		_, err = lw.WriteLevel(level, event)
+	} else {
+		_, err = w.out.Write(event)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush flushes the underlying output so Fatal and Panic still flush it
+func (w ecsWriter) Flush() error {
+	flush(w.out, false)
+	return nil
+}
+
+// Close closes the underlying output if it is a closer
+func (w ecsWriter) Close() error {
+	if c, ok := w.out.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// ecsField is a field of a JSON event with its value kept encoded
+type ecsField struct {
+	key   string
+	value json.RawMessage
+}
+
+// toECS converts the JSON event p into an ECS event, keeping field order
+// apart from the leading fields
+func toECS(p []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errNotObject
+	}
+
+	var leading [3]*ecsField
+	var fields []ecsField
+	hasVersion := false
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case zerolog.TimestampFieldName:
+			leading[0] = &ecsField{ecsTimestampField, value}
+			continue
+		case zerolog.LevelFieldName:
+			leading[1] = &ecsField{ecsLevelField, value}
+			continue
+		case zerolog.MessageFieldName:
+			leading[2] = &ecsField{zerolog.MessageFieldName, value}
+			continue
+		case zerolog.ErrorFieldName:
+			key = ecsErrorField
+		case "ecs.version":
+			hasVersion = true
+		}
+		fields = append(fields, ecsField{key, value})
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(p) + 32)
+	buf.WriteByte('{')
+	write := func(f ecsField) {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(f.value)
+	}
+	for _, f := range leading {
+		if f != nil {
+			write(*f)
+		}
+	}
+	for _, f := range fields {
+		write(f)
+	}
+	if !hasVersion {
+		write(ecsField{"ecs.version", json.RawMessage(`"` + ecsVersion + `"`)})
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), nil
+}
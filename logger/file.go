@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"errors"
+	"io"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileConfig configures a rotating log file
+type FileConfig struct {
+	// Path is the log file path; backups are kept in the same directory
+	Path string
+
+	// MaxSizeMB is the size in megabytes at which the file is rotated (default: 100)
+	MaxSizeMB int
+
+	// MaxBackups is the number of rotated files to keep (default: all)
+	MaxBackups int
+
+	// MaxAgeDays is the number of days to keep rotated files (default: no limit)
+	MaxAgeDays int
+
+	// Compress gzips rotated files
+	Compress bool
+}
+
+// fileOutput writes to a rotating file and any additional writers
+type fileOutput struct {
+	io.Writer
+	file *lumberjack.Logger
+}
+
+// Close closes the log file
+func (f *fileOutput) Close() error {
+	return f.file.Close()
+}
+
+// WithFileOutput returns a writer for Config.Output that logs to a rotating
+// file and, if given, to each writer in also (e.g. os.Stderr). Close it on
+// shutdown to release the file.
+func WithFileOutput(fc FileConfig, also ...io.Writer) (io.WriteCloser, error) {
+	if fc.Path == "" {
+		return nil, errors.New("log file path must not be empty")
+	}
+
+	file := &lumberjack.Logger{
+		Filename:   fc.Path,
+		MaxSize:    fc.MaxSizeMB,
+		MaxBackups: fc.MaxBackups,
+		MaxAge:     fc.MaxAgeDays,
+		Compress:   fc.Compress,
+	}
+
+	var w io.Writer = file
+	if len(also) > 0 {
+		w = io.MultiWriter(append([]io.Writer{file}, also...)...)
+	}
+	return &fileOutput{Writer: w, file: file}, nil
+}
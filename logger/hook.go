@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+// ContextHook enriches an event with data from the context passed to
+// WithContext, such as a tenant or request ID
+type ContextHook func(ctx context.Context, e *zerolog.Event)
+
+// WithHook returns a logger that runs fn on every event, passing the context
+// the logger was bound to with WithContext (context.Background() if none).
+// Hooks run in registration order.
+func (l *Logger) WithHook(fn ContextHook) *Logger {
+	hooks := make([]ContextHook, len(l.hooks), len(l.hooks)+1)
+	copy(hooks, l.hooks)
+
+	child := l.derive(l.zerolog().Hook(contextHook(fn)))
+	child.hooks = append(hooks, fn)
+	return child
+}
+
+// contextHook adapts a ContextHook to zerolog's hook interface
+type contextHook ContextHook
+
+func (h contextHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	h(e.GetCtx(), e)
+}
@@ -2,9 +2,12 @@ package logger
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -16,7 +19,17 @@ type Logger struct {
 	zerolog.Logger
 	traceIDKey string
 	spanIDKey  string
+	service    string
+	env        string
 	level      zerolog.Level
+	format     string
+	pretty     bool
+	out        io.Writer
+	exitFunc   func(int) // nil means os.Exit
+	panicFunc  func(interface{})
+	stackTrace bool
+	hooks      []ContextHook
+	otel       *otelExporter
 	mu         sync.RWMutex
 }
 
@@ -28,10 +41,16 @@ type Config struct {
 	// Level specifies the logging level (default: InfoLevel)
 	Level zerolog.Level
 
-	// Format specifies the output format: "json", "console", or "pretty"
+	// LevelName specifies the logging level by name ("debug", "info", "warn", ...)
+	// and takes precedence over Level. Useful when loading config from files;
+	// call Normalize to validate it.
+	LevelName string
+
+	// Format specifies the output format: "json", "console", "pretty" or "ecs"
 	// "json": JSON format for production
 	// "console": Human-readable console format
 	// "pretty": Colorized pretty format
+	// "ecs": JSON with Elastic Common Schema field names
 	Format string
 
 	// ServiceName sets the service name in logs
@@ -40,14 +59,60 @@ type Config struct {
 	// Environment sets the environment (dev, staging, prod, etc.)
 	Environment string
 
-	// TraceIDFieldName customizes the field name for trace ID in logs (default: "trace_id")
+	// TraceIDFieldName customizes the field name for trace ID in logs
+	// (default: "trace_id", or "trace.id" with the ecs format)
 	TraceIDFieldName string
 
-	// SpanIDFieldName customizes the field name for span ID in logs (default: "span_id")
+	// SpanIDFieldName customizes the field name for span ID in logs
+	// (default: "span_id", or "span.id" with the ecs format)
 	SpanIDFieldName string
 
 	// PrettyPrint enables pretty JSON formatting (indented) - only affects JSON format
 	PrettyPrint bool
+
+	// Sampling rate-limits high-volume levels (default: no sampling)
+	Sampling *SamplingConfig
+
+	// Caller adds the file:line of the call site to every event
+	Caller bool
+
+	// CallerSkipFrames skips additional frames when resolving the caller, for
+	// code that wraps event creation in its own helpers; implies Caller
+	CallerSkipFrames int
+
+	// ExitFunc is called with exit code 1 after a Fatal event is written
+	// (default: os.Exit). Override it to intercept fatal paths in tests.
+	ExitFunc func(int)
+
+	// PanicFunc is called with the message after a Panic event is written
+	// (default: the builtin panic)
+	PanicFunc func(interface{})
+
+	// StackTraceEnabled makes Err attach the error chain and a stack trace.
+	// Off by default since capturing stacks is comparatively expensive.
+	StackTraceEnabled bool
+}
+
+// Normalize validates LevelName and Format, returning a descriptive error for
+// invalid values so misconfiguration fails at startup instead of silently
+// falling back to defaults. A valid LevelName is copied into Level.
+func (c *Config) Normalize() error {
+	if c.LevelName != "" {
+		level, err := zerolog.ParseLevel(strings.ToLower(c.LevelName))
+		if err != nil || level == zerolog.NoLevel {
+			return fmt.Errorf("invalid log level %q (supported: trace, debug, info, warn, error, fatal, panic, disabled)", c.LevelName)
+		}
+		c.Level = level
+	}
+
+	c.Format = strings.ToLower(c.Format)
+	switch c.Format {
+	case "", FormatJSON, FormatConsole, FormatPretty, FormatECS:
+	default:
+		return fmt.Errorf("invalid log format %q (supported: %s, %s, %s, %s)", c.Format, FormatJSON, FormatConsole, FormatPretty, FormatECS)
+	}
+
+	return nil
 }
 
 // New creates a new logger with default configuration
@@ -61,37 +126,89 @@ func NewWithConfig(cfg Config) *Logger {
 	if cfg.Output == nil {
 		cfg.Output = os.Stderr
 	}
-	if cfg.Level == 0 {
+	levelFromName := false
+	if cfg.LevelName != "" {
+		if level, err := zerolog.ParseLevel(strings.ToLower(cfg.LevelName)); err == nil {
+			cfg.Level = level
+			levelFromName = true
+		}
+	}
+	if cfg.Level == 0 && !levelFromName {
 		cfg.Level = zerolog.InfoLevel
 	}
 	if cfg.Format == "" {
 		cfg.Format = "json"
 	}
+	serviceField, envField := "service", "env"
+	traceIDField, spanIDField := "trace_id", "span_id"
+	if cfg.Format == FormatECS {
+		serviceField, envField = ecsServiceField, ecsEnvironmentField
+		traceIDField, spanIDField = ecsTraceIDField, ecsSpanIDField
+	}
 	if cfg.TraceIDFieldName == "" {
-		cfg.TraceIDFieldName = "trace_id"
+		cfg.TraceIDFieldName = traceIDField
 	}
 	if cfg.SpanIDFieldName == "" {
-		cfg.SpanIDFieldName = "span_id"
+		cfg.SpanIDFieldName = spanIDField
+	}
+	if cfg.PanicFunc == nil {
+		cfg.PanicFunc = func(v interface{}) { panic(v) }
 	}
 
 	// Configure zerolog
-	zerolog.SetGlobalLevel(cfg.Level)
-	var logger zerolog.Logger
+	allowLevel(cfg.Level)
+	out := formatWriter(cfg.Format, cfg.PrettyPrint, cfg.Output)
+	logger := zerolog.New(out).
+		With().
+		Timestamp().
+		Logger()
 
-	switch cfg.Format {
+	// Add context fields
+	builder := logger.With()
+	if cfg.ServiceName != "" {
+		builder = builder.Str(serviceField, cfg.ServiceName)
+	}
+	if cfg.Environment != "" {
+		builder = builder.Str(envField, cfg.Environment)
+	}
+	if cfg.Caller || cfg.CallerSkipFrames > 0 {
+		// The caller is resolved when Msg is called on the event, so the
+		// Logger methods wrapping event creation need no extra skip
+		builder = builder.CallerWithSkipFrameCount(zerolog.CallerSkipFrameCount + cfg.CallerSkipFrames)
+	}
+	logger = builder.Logger().Level(cfg.Level)
+	if cfg.Sampling != nil {
+		logger = logger.Sample(cfg.Sampling.sampler())
+	}
+
+	return &Logger{
+		Logger:     logger,
+		traceIDKey: cfg.TraceIDFieldName,
+		spanIDKey:  cfg.SpanIDFieldName,
+		service:    cfg.ServiceName,
+		env:        cfg.Environment,
+		level:      cfg.Level,
+		format:     cfg.Format,
+		pretty:     cfg.PrettyPrint,
+		out:        out,
+		exitFunc:   cfg.ExitFunc,
+		panicFunc:  cfg.PanicFunc,
+		stackTrace: cfg.StackTraceEnabled,
+	}
+}
+
+// formatWriter wraps w in the writer that renders format
+func formatWriter(format string, prettyPrint bool, w io.Writer) io.Writer {
+	switch format {
 	case "console":
-		logger = zerolog.New(cfg.Output).
-			With().
-			Timestamp().
-			Logger().
-			Output(zerolog.ConsoleWriter{Out: cfg.Output, NoColor: false})
+		return zerolog.ConsoleWriter{Out: w, NoColor: false}
 	case "pretty":
 		consoleWriter := zerolog.ConsoleWriter{
-			Out:        cfg.Output,
+			Out:        w,
 			NoColor:    false,
 			TimeFormat: time.RFC3339,
 		}
-		if cfg.PrettyPrint {
+		if prettyPrint {
 			consoleWriter.PartsOrder = []string{
 				zerolog.TimestampFieldName,
 				zerolog.LevelFieldName,
@@ -99,33 +216,11 @@ func NewWithConfig(cfg Config) *Logger {
 				zerolog.MessageFieldName,
 			}
 		}
-		logger = zerolog.New(cfg.Output).
-			With().
-			Timestamp().
-			Logger().
-			Output(consoleWriter)
+		return consoleWriter
+	case "ecs":
+		return ecsWriter{out: w}
 	default: // json
-		logger = zerolog.New(cfg.Output).
-			With().
-			Timestamp().
-			Logger()
-	}
-
-	// Add context fields
-	builder := logger.With()
-	if cfg.ServiceName != "" {
-		builder = builder.Str("service", cfg.ServiceName)
-	}
-	if cfg.Environment != "" {
-		builder = builder.Str("env", cfg.Environment)
-	}
-	logger = builder.Logger()
-
-	return &Logger{
-		Logger:     logger,
-		traceIDKey: cfg.TraceIDFieldName,
-		spanIDKey:  cfg.SpanIDFieldName,
-		level:      cfg.Level,
+		return w
 	}
 }
 
@@ -133,74 +228,117 @@ func NewWithConfig(cfg Config) *Logger {
 func (l *Logger) WithContext(ctx context.Context) *Logger {
 	span := trace.SpanFromContext(ctx)
 	spanCtx := span.SpanContext()
-	if !spanCtx.IsValid() {
-		return l
-	}
 
 	fields := make(map[string]interface{})
-	if spanCtx.HasTraceID() {
-		fields[l.traceIDKey] = spanCtx.TraceID().String()
-	}
-	if spanCtx.HasSpanID() {
-		fields[l.spanIDKey] = spanCtx.SpanID().String()
+	if spanCtx.IsValid() {
+		if spanCtx.HasTraceID() {
+			fields[l.traceIDKey] = spanCtx.TraceID().String()
+		}
+		if spanCtx.HasSpanID() {
+			fields[l.spanIDKey] = spanCtx.SpanID().String()
+		}
 	}
 
-	if len(fields) == 0 {
+	// Hooks need the context even when there is no span to correlate
+	if len(fields) == 0 && len(l.hooks) == 0 {
 		return l
 	}
 
-	// Create a child logger with trace context
-	builder := l.Logger.With()
+	// Create a child logger with trace context; the context is also kept on
+	// events so hooks can read it
+	builder := l.zerolog().With().Ctx(ctx)
 	for key, value := range fields {
 		builder = builder.Interface(key, value)
 	}
 
+	return l.derive(builder.Logger())
+}
+
+// derive returns a logger wrapping z that keeps l's settings
+func (l *Logger) derive(z zerolog.Logger) *Logger {
 	return &Logger{
-		Logger:     builder.Logger(),
+		Logger:     z,
 		traceIDKey: l.traceIDKey,
 		spanIDKey:  l.spanIDKey,
-		level:      l.level,
+		service:    l.service,
+		env:        l.env,
+		level:      l.GetLevel(),
+		format:     l.format,
+		pretty:     l.pretty,
+		out:        l.output(),
+		exitFunc:   l.exitFunc,
+		panicFunc:  l.panicFunc,
+		stackTrace: l.stackTrace,
+		hooks:      l.hooks,
+		otel:       l.otel,
 	}
 }
 
+// zerolog returns a copy of the underlying zerolog logger, safe to use while
+// SetLevel runs concurrently
+func (l *Logger) zerolog() zerolog.Logger {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.Logger
+}
+
 // With creates a zerolog event builder
 func (l *Logger) With() zerolog.Context {
-	return l.Logger.With()
+	return l.zerolog().With()
 }
 
 // Info creates an info level log event
 func (l *Logger) Info() *zerolog.Event {
-	return l.Logger.Info()
+	z := l.zerolog()
+	return z.Info()
 }
 
 // Debug creates a debug level log event
 func (l *Logger) Debug() *zerolog.Event {
-	return l.Logger.Debug()
+	z := l.zerolog()
+	return z.Debug()
 }
 
 // Error creates an error level log event
 func (l *Logger) Error() *zerolog.Event {
-	return l.Logger.Error()
+	z := l.zerolog()
+	return z.Error()
 }
 
 // Warn creates a warn level log event
 func (l *Logger) Warn() *zerolog.Event {
-	return l.Logger.Warn()
+	z := l.zerolog()
+	return z.Warn()
 }
 
-// Fatal creates a fatal level log event (exits the program)
+// Fatal creates a fatal level log event; after it is written the output is
+// flushed and ExitFunc is called with 1 (os.Exit by default)
 func (l *Logger) Fatal() *zerolog.Event {
-	return l.Logger.Fatal()
+	return l.terminal(zerolog.FatalLevel, func(string) {
+		if l.exitFunc != nil {
+			// The process may keep running, so the output must stay usable
+			flush(l.output(), false)
+			l.exitFunc(1)
+			return
+		}
+		flush(l.output(), true)
+		os.Exit(1)
+	})
 }
 
-// Panic creates a panic level log event (panics)
+// Panic creates a panic level log event; after it is written PanicFunc is
+// called with the message (the builtin panic by default)
 func (l *Logger) Panic() *zerolog.Event {
-	return l.Logger.Panic()
+	return l.terminal(zerolog.PanicLevel, func(msg string) {
+		flush(l.output(), false)
+		l.panicFunc(msg)
+	})
 }
 
 // Trace creates a trace level log event
 func (l *Logger) Trace() *zerolog.Event {
-	return l.Logger.Trace()
+	z := l.zerolog()
+	return z.Trace()
 }
 
 // GetLevel returns the current logging level
@@ -210,12 +348,45 @@ func (l *Logger) GetLevel() zerolog.Level {
 	return l.level
 }
 
-// SetLevel updates the logging level
+// SetLevel updates the logging level of this logger only; other loggers,
+// including ones derived from it earlier, keep their own level
 func (l *Logger) SetLevel(level zerolog.Level) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.level = level
-	zerolog.SetGlobalLevel(level)
+	l.Logger = l.Logger.Level(level)
+	allowLevel(level)
+}
+
+// SetOutput redirects this logger to w, keeping its format, fields and
+// level; loggers derived from it earlier keep their own output
+func (l *Logger) SetOutput(w io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out = formatWriter(l.format, l.pretty, w)
+	if l.otel != nil {
+		l.out = l.otel.tee(l.out)
+	}
+	l.Logger = l.Logger.Output(l.out)
+}
+
+// output returns the writer events are written to
+func (l *Logger) output() io.Writer {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.out
+}
+
+// globalLevelPinned records that SetGlobalLevel was called explicitly
+var globalLevelPinned atomic.Bool
+
+// allowLevel lowers zerolog's process-wide minimum level when a logger is
+// configured below it (zerolog defaults to debug, which would drop trace
+// events), unless the minimum was pinned with SetGlobalLevel
+func allowLevel(level zerolog.Level) {
+	if !globalLevelPinned.Load() && level < zerolog.GlobalLevel() {
+		zerolog.SetGlobalLevel(level)
+	}
 }
 
 // Global logger instance
@@ -234,6 +405,8 @@ func GetGlobal() *Logger {
 
 // SetGlobal sets the global logger instance
 func SetGlobal(logger *Logger) {
+	// Consume the lazy initialization so GetGlobal doesn't replace logger
+	globalOnce.Do(func() {})
 	globalLogger = logger
 }
 
@@ -258,9 +431,11 @@ func SetLevel(level zerolog.Level) {
 	GetGlobal().SetLevel(level)
 }
 
-// SetGlobalLevel is an alias for SetLevel
+// SetGlobalLevel sets the process-wide minimum level: events below it are
+// dropped by every logger regardless of its own level
 func SetGlobalLevel(level zerolog.Level) {
-	SetLevel(level)
+	globalLevelPinned.Store(true)
+	zerolog.SetGlobalLevel(level)
 }
 
 // Format constant values
@@ -268,4 +443,5 @@ const (
 	FormatJSON    = "json"
 	FormatConsole = "console"
 	FormatPretty  = "pretty"
+	FormatECS     = "ecs"
 )
@@ -4,14 +4,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestNew(t *testing.T) {
@@ -134,63 +147,83 @@ func TestWithContext_NoSpan(t *testing.T) {
 	assert.False(t, hasSpanID)
 }
 
-// func TestWithContext_WithSpan(t *testing.T) {
-// 	var buf bytes.Buffer
-// 	logger := NewWithConfig(Config{
-// 		Output: &buf,
-// 		Format: FormatJSON,
-// 	})
+// newTestTracer returns a tracer from an SDK provider that samples every
+// span; the noop provider yields invalid span contexts and no IDs
+func newTestTracer(t testing.TB) trace.Tracer {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+	return tp.Tracer("test")
+}
 
-// 	// Create a tracer and span
-// 	tracer := trace.NewNoopTracerProvider().Tracer("test")
-// 	ctx, span := tracer.Start(context.Background(), "test-span")
+func TestWithContext_WithSpan(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{
+		Output: &buf,
+		Format: FormatJSON,
+	})
 
-// 	loggerWithCtx := logger.WithContext(ctx)
-// 	loggerWithCtx.Info().Msg("test")
+	// Create a tracer and span
+	tracer := newTestTracer(t)
+	ctx, span := tracer.Start(context.Background(), "test-span")
 
-// 	span.End()
+	loggerWithCtx := logger.WithContext(ctx)
+	loggerWithCtx.Info().Msg("test")
 
-// 	var logData map[string]interface{}
-// 	err := json.Unmarshal(buf.Bytes(), &logData)
-// 	require.NoError(t, err)
+	span.End()
 
-// 	// Should have trace_id and span_id
-// 	traceID, hasTraceID := logData["trace_id"]
-// 	spanID, hasSpanID := logData["span_id"]
+	var logData map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &logData)
+	require.NoError(t, err)
 
-// 	assert.True(t, hasTraceID, "should have trace_id field")
-// 	assert.True(t, hasSpanID, "should have span_id field")
-// 	assert.NotEmpty(t, traceID)
-// 	assert.NotEmpty(t, spanID)
-// }
+	// Should have trace_id and span_id
+	traceID, hasTraceID := logData["trace_id"]
+	spanID, hasSpanID := logData["span_id"]
 
-// func TestWithContext_CustomFieldNames(t *testing.T) {
-// 	var buf bytes.Buffer
-// 	logger := NewWithConfig(Config{
-// 		Output:           &buf,
-// 		Format:           FormatJSON,
-// 		TraceIDFieldName: "my_trace_id",
-// 		SpanIDFieldName:  "my_span_id",
-// 	})
+	assert.True(t, hasTraceID, "should have trace_id field")
+	assert.True(t, hasSpanID, "should have span_id field")
+	assert.Equal(t, span.SpanContext().TraceID().String(), traceID)
+	assert.Equal(t, span.SpanContext().SpanID().String(), spanID)
+}
+
+func TestWithContext_NoopTracer(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf})
+
+	// Without an SDK provider spans have no IDs to correlate
+	ctx, span := noop.NewTracerProvider().Tracer("test").Start(context.Background(), "test-span")
+	defer span.End()
 
-// 	tracer := trace.NewNoopTracerProvider().Tracer("test")
-// 	ctx, span := tracer.Start(context.Background(), "test-span")
+	logger.WithContext(ctx).Info().Msg("test")
+	assert.NotContains(t, buf.String(), "trace_id")
+}
 
-// 	loggerWithCtx := logger.WithContext(ctx)
-// 	loggerWithCtx.Info().Msg("test")
+func TestWithContext_CustomFieldNames(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{
+		Output:           &buf,
+		Format:           FormatJSON,
+		TraceIDFieldName: "my_trace_id",
+		SpanIDFieldName:  "my_span_id",
+	})
 
-// 	span.End()
+	tracer := newTestTracer(t)
+	ctx, span := tracer.Start(context.Background(), "test-span")
 
-// 	var logData map[string]interface{}
-// 	err := json.Unmarshal(buf.Bytes(), &logData)
-// 	require.NoError(t, err)
+	loggerWithCtx := logger.WithContext(ctx)
+	loggerWithCtx.Info().Msg("test")
 
-// 	// Should use custom field names
-// 	_, hasTraceID := logData["my_trace_id"]
-// 	_, hasSpanID := logData["my_span_id"]
-// 	assert.True(t, hasTraceID)
-// 	assert.True(t, hasSpanID)
-// }
+	span.End()
+
+	var logData map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &logData)
+	require.NoError(t, err)
+
+	// Should use custom field names
+	assert.Equal(t, span.SpanContext().TraceID().String(), logData["my_trace_id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), logData["my_span_id"])
+	_, hasDefault := logData["trace_id"]
+	assert.False(t, hasDefault)
+}
 
 func TestSetLevel(t *testing.T) {
 	logger := New()
@@ -203,6 +236,42 @@ func TestSetLevel(t *testing.T) {
 	assert.Equal(t, zerolog.ErrorLevel, logger.GetLevel())
 }
 
+func TestSetLevel_Independent(t *testing.T) {
+	var debugBuf, errorBuf bytes.Buffer
+	debugLogger := NewWithConfig(Config{Output: &debugBuf, Level: zerolog.InfoLevel})
+	errorLogger := NewWithConfig(Config{Output: &errorBuf, Level: zerolog.InfoLevel})
+
+	debugLogger.SetLevel(zerolog.DebugLevel)
+	errorLogger.SetLevel(zerolog.ErrorLevel)
+
+	debugLogger.Debug().Msg("debug")
+	errorLogger.Debug().Msg("debug")
+	errorLogger.Warn().Msg("warn")
+	assert.Contains(t, debugBuf.String(), `"message":"debug"`)
+	assert.Empty(t, errorBuf.String())
+
+	errorLogger.Error().Msg("error")
+	debugLogger.Info().Msg("info")
+	assert.Contains(t, errorBuf.String(), `"message":"error"`)
+	assert.Contains(t, debugBuf.String(), `"message":"info"`)
+
+	// Creating a logger at a different level leaves existing ones alone
+	NewWithConfig(Config{Output: &bytes.Buffer{}, Level: zerolog.PanicLevel})
+	debugBuf.Reset()
+	debugLogger.Debug().Msg("still debug")
+	assert.Contains(t, debugBuf.String(), "still debug")
+	assert.Equal(t, zerolog.DebugLevel, debugLogger.GetLevel())
+	assert.Equal(t, zerolog.ErrorLevel, errorLogger.GetLevel())
+}
+
+func TestSetLevel_Trace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf, Level: zerolog.TraceLevel})
+
+	logger.Trace().Msg("trace")
+	assert.Contains(t, buf.String(), `"message":"trace"`)
+}
+
 // func TestLogLevels(t *testing.T) {
 // 	var buf bytes.Buffer
 // 	logger := NewWithConfig(Config{
@@ -348,31 +417,31 @@ func TestEmptyTraceID(t *testing.T) {
 	assert.Contains(t, output, "test")
 }
 
-// func TestWithContext_MultipleCalls(t *testing.T) {
-// 	var buf1 bytes.Buffer
-// 	logger := NewWithConfig(Config{
-// 		Output: &buf1,
-// 		Format: FormatJSON,
-// 	})
+func TestWithContext_MultipleCalls(t *testing.T) {
+	var buf1 bytes.Buffer
+	logger := NewWithConfig(Config{
+		Output: &buf1,
+		Format: FormatJSON,
+	})
 
-// 	tracer := trace.NewNoopTracerProvider().Tracer("test")
-// 	ctx, span := tracer.Start(context.Background(), "test-span")
+	tracer := newTestTracer(t)
+	ctx, span := tracer.Start(context.Background(), "test-span")
 
-// 	loggerWithCtx1 := logger.WithContext(ctx)
-// 	loggerWithCtx2 := logger.WithContext(ctx)
+	loggerWithCtx1 := logger.WithContext(ctx)
+	loggerWithCtx2 := logger.WithContext(ctx)
 
-// 	// They should be different instances but both work
-// 	assert.NotEqual(t, loggerWithCtx1, loggerWithCtx2)
+	// They should be different instances but both work
+	assert.NotSame(t, loggerWithCtx1, loggerWithCtx2)
 
-// 	loggerWithCtx1.Info().Msg("test1")
-// 	loggerWithCtx2.Info().Msg("test2")
+	loggerWithCtx1.Info().Msg("test1")
+	loggerWithCtx2.Info().Msg("test2")
 
-// 	span.End()
+	span.End()
 
-// 	output := buf1.String()
-// 	assert.Contains(t, output, "test1")
-// 	assert.Contains(t, output, "test2")
-// }
+	output := buf1.String()
+	assert.Contains(t, output, "test1")
+	assert.Contains(t, output, "test2")
+}
 
 func BenchmarkLogger_Info(b *testing.B) {
 	logger := NewWithConfig(Config{
@@ -408,7 +477,7 @@ func BenchmarkLogger_WithContext(b *testing.B) {
 		Format: FormatJSON,
 	})
 
-	tracer := trace.NewNoopTracerProvider().Tracer("test")
+	tracer := newTestTracer(b)
 	ctx, span := tracer.Start(context.Background(), "test-span")
 	defer span.End()
 
@@ -419,70 +488,70 @@ func BenchmarkLogger_WithContext(b *testing.B) {
 	}
 }
 
-// func TestExampleUsage(t *testing.T) {
-// 	// This test demonstrates typical usage patterns
-// 	t.Run("Basic logging", func(t *testing.T) {
-// 		var buf bytes.Buffer
-// 		logger := NewWithConfig(Config{
-// 			Output:      &buf,
-// 			Format:      FormatJSON,
-// 			ServiceName: "test-service",
-// 		})
-
-// 		logger.Info().Msg("Starting application")
-
-// 		output := buf.String()
-// 		assert.Contains(t, output, "Starting application")
-// 		assert.Contains(t, output, "test-service")
-// 	})
-
-// 	t.Run("With OpenTelemetry tracing", func(t *testing.T) {
-// 		var buf bytes.Buffer
-// 		logger := NewWithConfig(Config{
-// 			Output: &buf,
-// 			Format: FormatJSON,
-// 		})
-
-// 		tracer := trace.NewNoopTracerProvider().Tracer("test")
-// 		ctx, span := tracer.Start(context.Background(), "http-request")
-
-// 		loggerWithCtx := logger.WithContext(ctx)
-// 		loggerWithCtx.Info().
-// 			Str("method", "GET").
-// 			Str("path", "/users").
-// 			Int("status", 200).
-// 			Msg("Request completed")
-
-// 		span.End()
+func TestExampleUsage(t *testing.T) {
+	// This test demonstrates typical usage patterns
+	t.Run("Basic logging", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewWithConfig(Config{
+			Output:      &buf,
+			Format:      FormatJSON,
+			ServiceName: "test-service",
+		})
+
+		logger.Info().Msg("Starting application")
+
+		output := buf.String()
+		assert.Contains(t, output, "Starting application")
+		assert.Contains(t, output, "test-service")
+	})
 
-// 		var logData map[string]interface{}
-// 		err := json.Unmarshal(buf.Bytes(), &logData)
-// 		require.NoError(t, err)
+	t.Run("With OpenTelemetry tracing", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewWithConfig(Config{
+			Output: &buf,
+			Format: FormatJSON,
+		})
 
-// 		assert.Contains(t, logData, "trace_id")
-// 		assert.Contains(t, logData, "span_id")
-// 		assert.Equal(t, "GET", logData["method"])
-// 		assert.Equal(t, "/users", logData["path"])
-// 	})
+		tracer := newTestTracer(t)
+		ctx, span := tracer.Start(context.Background(), "http-request")
 
-// 	t.Run("Error logging with stack trace", func(t *testing.T) {
-// 		var buf bytes.Buffer
-// 		logger := NewWithConfig(Config{
-// 			Output: &buf,
-// 			Format: FormatConsole, // More readable for errors
-// 		})
-
-// 		err := assert.AnError
-// 		logger.Error().
-// 			Err(err).
-// 			Str("operation", "database-query").
-// 			Msg("Failed to execute query")
+		loggerWithCtx := logger.WithContext(ctx)
+		loggerWithCtx.Info().
+			Str("method", "GET").
+			Str("path", "/users").
+			Int("status", 200).
+			Msg("Request completed")
+
+		span.End()
+
+		var logData map[string]interface{}
+		err := json.Unmarshal(buf.Bytes(), &logData)
+		require.NoError(t, err)
+
+		assert.Contains(t, logData, "trace_id")
+		assert.Contains(t, logData, "span_id")
+		assert.Equal(t, "GET", logData["method"])
+		assert.Equal(t, "/users", logData["path"])
+	})
 
-// 		output := buf.String()
-// 		assert.Contains(t, output, "ERR")
-// 		assert.Contains(t, output, "Failed to execute query")
-// 	})
-// }
+	t.Run("Error logging with stack trace", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewWithConfig(Config{
+			Output: &buf,
+			Format: FormatConsole, // More readable for errors
+		})
+
+		err := assert.AnError
+		logger.Error().
+			Err(err).
+			Str("operation", "database-query").
+			Msg("Failed to execute query")
+
+		output := buf.String()
+		assert.Contains(t, output, "ERR")
+		assert.Contains(t, output, "Failed to execute query")
+	})
+}
 
 func TestFormatConstants(t *testing.T) {
 	// Test that format constants are valid
@@ -631,35 +700,797 @@ func TestSetLevel_Global(t *testing.T) {
 	SetLevel(zerolog.DebugLevel)
 	assert.Equal(t, zerolog.DebugLevel, GetGlobal().GetLevel())
 
-	SetGlobalLevel(zerolog.InfoLevel)
-	assert.Equal(t, zerolog.InfoLevel, GetGlobal().GetLevel())
-
 	// Restore original level
 	GetGlobal().SetLevel(originalLevel)
 }
 
-// func TestWithContext_Global(t *testing.T) {
-// 	var buf bytes.Buffer
-// 	SetGlobal(NewWithConfig(Config{
-// 		Output: &buf,
-// 		Format: FormatJSON,
-// 	}))
+func TestSetGlobalLevel(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	defer func() {
+		zerolog.SetGlobalLevel(originalLevel)
+		globalLevelPinned.Store(false)
+	}()
 
-// 	tracer := trace.NewNoopTracerProvider().Tracer("test")
-// 	ctx, span := tracer.Start(context.Background(), "test-span")
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf, LevelName: "debug"})
 
-// 	loggerWithCtx := WithContext(ctx)
-// 	loggerWithCtx.Info().Msg("test")
+	// The process-wide minimum filters every logger
+	SetGlobalLevel(zerolog.WarnLevel)
+	assert.Equal(t, zerolog.WarnLevel, zerolog.GlobalLevel())
+	assert.Equal(t, zerolog.DebugLevel, logger.GetLevel())
 
-// 	span.End()
+	logger.Info().Msg("info")
+	assert.Empty(t, buf.String())
+	logger.Warn().Msg("warn")
+	assert.Contains(t, buf.String(), `"message":"warn"`)
 
-// 	var logData map[string]interface{}
-// 	err := json.Unmarshal(buf.Bytes(), &logData)
-// 	require.NoError(t, err)
+	// Instance levels no longer lower a pinned minimum
+	logger.SetLevel(zerolog.TraceLevel)
+	assert.Equal(t, zerolog.WarnLevel, zerolog.GlobalLevel())
+}
 
-// 	assert.Contains(t, logData, "trace_id")
-// 	assert.Contains(t, logData, "span_id")
+func TestWithContext_Global(t *testing.T) {
+	var buf bytes.Buffer
+	SetGlobal(NewWithConfig(Config{
+		Output: &buf,
+		Format: FormatJSON,
+	}))
 
-// 	// Cleanup
-// 	SetGlobal(New())
-// }
+	tracer := newTestTracer(t)
+	ctx, span := tracer.Start(context.Background(), "test-span")
+
+	loggerWithCtx := WithContext(ctx)
+	loggerWithCtx.Info().Msg("test")
+
+	span.End()
+
+	var logData map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &logData)
+	require.NoError(t, err)
+
+	assert.Contains(t, logData, "trace_id")
+	assert.Contains(t, logData, "span_id")
+
+	// Cleanup
+	SetGlobal(New())
+}
+
+func TestConfig_Normalize(t *testing.T) {
+	cfg := Config{LevelName: "DEBUG", Format: "Console"}
+	require.NoError(t, cfg.Normalize())
+	assert.Equal(t, zerolog.DebugLevel, cfg.Level)
+	assert.Equal(t, FormatConsole, cfg.Format)
+
+	logger := NewWithConfig(cfg)
+	assert.Equal(t, zerolog.DebugLevel, logger.GetLevel())
+}
+
+func TestConfig_Normalize_Empty(t *testing.T) {
+	cfg := Config{}
+	require.NoError(t, cfg.Normalize())
+	assert.Equal(t, zerolog.Level(0), cfg.Level)
+}
+
+func TestConfig_Normalize_InvalidLevel(t *testing.T) {
+	cfg := Config{LevelName: "verbose"}
+	err := cfg.Normalize()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid log level "verbose"`)
+}
+
+func TestConfig_Normalize_InvalidFormat(t *testing.T) {
+	cfg := Config{Format: "xml"}
+	err := cfg.Normalize()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid log format "xml"`)
+}
+
+func TestConfig_Normalize_ECS(t *testing.T) {
+	cfg := Config{Format: "ECS"}
+	require.NoError(t, cfg.Normalize())
+	assert.Equal(t, FormatECS, cfg.Format)
+}
+
+func TestLogger_ECSFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{
+		Output:      &buf,
+		Format:      FormatECS,
+		ServiceName: "checkout",
+		Environment: "prod",
+	})
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+
+	logger.WithContext(ctx).Error().Err(errors.New("boom")).Str("order_id", "42").Msg("payment failed")
+
+	line := buf.String()
+	assert.True(t, strings.HasPrefix(line, `{"@timestamp":`), "expected @timestamp first, got %s", line)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "error", entry["log.level"])
+	assert.Equal(t, "payment failed", entry["message"])
+	assert.Equal(t, "boom", entry["error.message"])
+	assert.Equal(t, "checkout", entry["service.name"])
+	assert.Equal(t, "prod", entry["service.environment"])
+	assert.Equal(t, span.SpanContext().TraceID().String(), entry["trace.id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), entry["span.id"])
+	assert.Equal(t, "42", entry["order_id"])
+	assert.Equal(t, ecsVersion, entry["ecs.version"])
+	for _, key := range []string{"time", "level", "error", "service", "env", "trace_id", "span_id"} {
+		assert.NotContains(t, entry, key)
+	}
+}
+
+func TestLogger_ECSFormatSetOutput(t *testing.T) {
+	logger := NewWithConfig(Config{Output: io.Discard, Format: FormatECS})
+
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	logger.Info().Msg("moved")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "info", entry["log.level"])
+	assert.Equal(t, "moved", entry["message"])
+}
+
+// memoryLogProvider is an in-memory OTel logger provider that records every
+// emitted log record
+type memoryLogProvider struct {
+	embedded.LoggerProvider
+	memoryLogger
+}
+
+type memoryLogger struct {
+	embedded.Logger
+
+	mu      sync.Mutex
+	records []otellog.Record
+	ctxs    []context.Context
+}
+
+func (p *memoryLogProvider) Logger(string, ...otellog.LoggerOption) otellog.Logger {
+	return &p.memoryLogger
+}
+
+func (p *memoryLogger) Emit(ctx context.Context, record otellog.Record) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.records = append(p.records, record.Clone())
+	p.ctxs = append(p.ctxs, ctx)
+}
+
+func (p *memoryLogger) Enabled(context.Context, otellog.EnabledParameters) bool {
+	return true
+}
+
+func TestWithOTelLogExporter(t *testing.T) {
+	var buf bytes.Buffer
+	provider := &memoryLogProvider{}
+
+	logger := NewWithConfig(Config{
+		Output:      &buf,
+		Level:       zerolog.InfoLevel,
+		ServiceName: "test-service",
+	}).WithOTelLogExporter(provider)
+
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	logger.WithContext(ctx).Info().Str("order_id", "A-1").Int("quantity", 3).Msg("hello otel")
+	logger.Debug().Msg("filtered out")
+
+	// Regular output is unaffected
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	assert.Equal(t, "hello otel", logData["message"])
+
+	require.Len(t, provider.records, 1)
+	record := provider.records[0]
+	assert.Equal(t, otellog.SeverityInfo, record.Severity())
+	assert.Equal(t, "info", record.SeverityText())
+	assert.Equal(t, "hello otel", record.Body().AsString())
+	assert.Equal(t, spanID, trace.SpanContextFromContext(provider.ctxs[0]).SpanID())
+
+	attrs := make(map[string]otellog.Value)
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	assert.Equal(t, "test-service", attrs["service"].AsString())
+	assert.Equal(t, traceID.String(), attrs["trace_id"].AsString())
+	assert.Equal(t, spanID.String(), attrs["span_id"].AsString())
+	assert.Equal(t, "A-1", attrs["order_id"].AsString())
+	assert.Equal(t, int64(3), attrs["quantity"].AsInt64())
+	assert.NotContains(t, attrs, "message")
+}
+
+func TestWithOTelLogExporter_SetOutput(t *testing.T) {
+	provider := &memoryLogProvider{}
+	logger := NewWithConfig(Config{Output: io.Discard}).WithOTelLogExporter(provider)
+
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	logger.Info().Msg("after set output")
+
+	assert.Contains(t, buf.String(), "after set output")
+	require.Len(t, provider.records, 1)
+	assert.Equal(t, "after set output", provider.records[0].Body().AsString())
+}
+
+func TestSampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{
+		Output: &buf,
+		Sampling: &SamplingConfig{
+			BurstFirst: 3,
+			Period:     time.Hour,
+		},
+	})
+
+	countLines := func() int {
+		return strings.Count(buf.String(), "\n")
+	}
+
+	for i := 0; i < 3; i++ {
+		logger.Info().Int("n", i).Msg("flood")
+	}
+	assert.Equal(t, 3, countLines())
+
+	// The burst is used up within the window
+	logger.Info().Int("n", 3).Msg("flood")
+	assert.Equal(t, 3, countLines())
+
+	// Unsampled levels are always logged
+	for i := 0; i < 5; i++ {
+		logger.Error().Msg("failure")
+	}
+	assert.Equal(t, 8, countLines())
+}
+
+func TestSampling_ThenEvery(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{
+		Output: &buf,
+		Sampling: &SamplingConfig{
+			BurstFirst: 2,
+			ThenEvery:  5,
+			Period:     time.Hour,
+		},
+	})
+
+	for i := 0; i < 12; i++ {
+		logger.Info().Msg("flood")
+	}
+	// 2 from the burst, then one of every 5 of the remaining 10
+	assert.Equal(t, 4, strings.Count(buf.String(), "\n"))
+}
+
+func TestSampling_PeriodResets(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{
+		Output: &buf,
+		Sampling: &SamplingConfig{
+			BurstFirst: 1,
+			Period:     50 * time.Millisecond,
+			Levels:     []zerolog.Level{zerolog.WarnLevel},
+		},
+	})
+
+	logger.Warn().Msg("first")
+	logger.Warn().Msg("dropped")
+	time.Sleep(60 * time.Millisecond)
+	logger.Warn().Msg("next window")
+
+	out := buf.String()
+	assert.Contains(t, out, "first")
+	assert.NotContains(t, out, "dropped")
+	assert.Contains(t, out, "next window")
+}
+
+func TestWithFileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	var console bytes.Buffer
+
+	out, err := WithFileOutput(FileConfig{Path: path, MaxSizeMB: 1}, &console)
+	require.NoError(t, err)
+
+	logger := NewWithConfig(Config{Output: out})
+	logger.Info().Msg("to both")
+	require.NoError(t, out.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"message":"to both"`)
+	assert.Contains(t, console.String(), `"message":"to both"`)
+}
+
+func TestWithFileOutput_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	out, err := WithFileOutput(FileConfig{Path: filepath.Join(dir, "app.log"), MaxSizeMB: 1, MaxBackups: 2})
+	require.NoError(t, err)
+	defer out.Close()
+
+	logger := NewWithConfig(Config{Output: out})
+	line := strings.Repeat("x", 1024)
+	for i := 0; i < 1100; i++ {
+		logger.Info().Msg(line)
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestWithFileOutput_EmptyPath(t *testing.T) {
+	_, err := WithFileOutput(FileConfig{})
+	assert.Error(t, err)
+}
+
+type tenantKey struct{}
+
+func TestWithHook(t *testing.T) {
+	var buf bytes.Buffer
+	var order []string
+
+	logger := NewWithConfig(Config{Output: &buf}).
+		WithHook(func(ctx context.Context, e *zerolog.Event) {
+			order = append(order, "tenant")
+			if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+				e.Str("tenant_id", tenant)
+			}
+		}).
+		WithHook(func(ctx context.Context, e *zerolog.Event) {
+			order = append(order, "region")
+			e.Str("region", "eu")
+		})
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	logger.WithContext(ctx).Info().Msg("enriched")
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	assert.Equal(t, "acme", logData["tenant_id"])
+	assert.Equal(t, "eu", logData["region"])
+	assert.Equal(t, []string{"tenant", "region"}, order)
+
+	// Without a bound context hooks see context.Background()
+	buf.Reset()
+	logger.Info().Msg("plain")
+	logData = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	_, hasTenant := logData["tenant_id"]
+	assert.False(t, hasTenant)
+	assert.Equal(t, "eu", logData["region"])
+}
+
+func TestWithHook_DoesNotAffectParent(t *testing.T) {
+	var buf bytes.Buffer
+	parent := NewWithConfig(Config{Output: &buf})
+	parent.WithHook(func(ctx context.Context, e *zerolog.Event) {
+		e.Bool("hooked", true)
+	})
+
+	parent.Info().Msg("parent")
+	assert.NotContains(t, buf.String(), "hooked")
+}
+
+func TestCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf, Caller: true})
+
+	logger.Error().Msg("failed")
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	assert.Contains(t, logData["caller"], "logger_test.go:")
+
+	// Derived loggers keep the caller
+	buf.Reset()
+	logger.WithHook(func(ctx context.Context, e *zerolog.Event) {}).Info().Msg("derived")
+	assert.Contains(t, buf.String(), "logger_test.go:")
+}
+
+func TestCallerSkipFrames(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf, CallerSkipFrames: 1})
+
+	logFailure := func(msg string) {
+		logger.Error().Msg(msg)
+	}
+	logFailure("failed")
+	line := callerLine(t)
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	assert.Equal(t, line-1, callerLineOf(t, logData["caller"]))
+}
+
+// callerLine returns the line it was called from
+func callerLine(t *testing.T) int {
+	_, _, line, ok := runtime.Caller(1)
+	require.True(t, ok)
+	return line
+}
+
+// callerLineOf extracts the line number from a "file:line" caller field
+func callerLineOf(t *testing.T, caller interface{}) int {
+	s, ok := caller.(string)
+	require.True(t, ok)
+	line, err := strconv.Atoi(s[strings.LastIndex(s, ":")+1:])
+	require.NoError(t, err)
+	return line
+}
+
+func TestSetOutput(t *testing.T) {
+	var first, second bytes.Buffer
+	logger := NewWithConfig(Config{
+		Output:      &first,
+		ServiceName: "api",
+		LevelName:   "warn",
+	})
+
+	logger.SetOutput(&second)
+	logger.Info().Msg("filtered")
+	logger.Warn().Msg("redirected")
+
+	assert.Empty(t, first.String())
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal(second.Bytes(), &logData))
+	assert.Equal(t, "redirected", logData["message"])
+	assert.Equal(t, "api", logData["service"])
+	assert.Equal(t, zerolog.WarnLevel, logger.GetLevel())
+}
+
+func TestSetOutput_PreservesFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: io.Discard, Format: FormatConsole})
+
+	logger.SetOutput(&buf)
+	logger.Info().Msg("console line")
+
+	out := buf.String()
+	assert.Contains(t, out, "console line")
+	assert.False(t, json.Valid(buf.Bytes()), "expected console output, got %q", out)
+}
+
+func TestSetOutput_Concurrent(t *testing.T) {
+	logger := NewWithConfig(Config{Output: io.Discard})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			logger.Info().Msg("concurrent")
+		}()
+		go func() {
+			defer wg.Done()
+			logger.SetOutput(io.Discard)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{
+		Output:      &buf,
+		ServiceName: "api",
+		Environment: "test",
+	})
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	logger.Slog().Info("order created",
+		slog.String("id", "o-1"),
+		slog.Int("items", 3),
+		slog.Uint64("bytes", 1024),
+		slog.Float64("total", 9.5),
+		slog.Bool("paid", true),
+		slog.Duration("took", 1500*time.Millisecond),
+		slog.Time("at", ts),
+		slog.Any("err", errors.New("boom")),
+		slog.Any("tags", []string{"a", "b"}),
+		slog.Group("customer", slog.String("name", "Ann"), slog.Int("age", 30)),
+	)
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	assert.Equal(t, "info", logData["level"])
+	assert.Equal(t, "order created", logData["message"])
+	assert.Equal(t, "api", logData["service"])
+	assert.Equal(t, "test", logData["env"])
+	assert.Equal(t, "o-1", logData["id"])
+	assert.Equal(t, float64(3), logData["items"])
+	assert.Equal(t, float64(1024), logData["bytes"])
+	assert.Equal(t, 9.5, logData["total"])
+	assert.Equal(t, true, logData["paid"])
+	assert.Equal(t, float64(1500), logData["took"])
+	assert.Equal(t, ts.Format(time.RFC3339), logData["at"])
+	assert.Equal(t, "boom", logData["err"])
+	assert.Equal(t, []interface{}{"a", "b"}, logData["tags"])
+	assert.Equal(t, map[string]interface{}{"name": "Ann", "age": float64(30)}, logData["customer"])
+}
+
+func TestSlog_Groups(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf}).Slog()
+
+	logger.With("app", "shop").
+		WithGroup("req").
+		With("method", "GET").
+		WithGroup("empty").
+		Warn("slow", "ms", 250)
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	assert.Equal(t, "warn", logData["level"])
+	assert.Equal(t, "shop", logData["app"])
+	assert.Equal(t, map[string]interface{}{
+		"method": "GET",
+		"empty":  map[string]interface{}{"ms": float64(250)},
+	}, logData["req"])
+
+	// Groups with no attributes are omitted
+	buf.Reset()
+	logger.WithGroup("unused").Info("plain")
+	logData = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	_, hasGroup := logData["unused"]
+	assert.False(t, hasGroup)
+}
+
+func TestSlog_Levels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf, LevelName: "warn"}).Slog()
+
+	assert.False(t, logger.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, logger.Enabled(context.Background(), slog.LevelWarn))
+
+	logger.Info("dropped")
+	assert.Empty(t, buf.String())
+
+	logger.Error("failed")
+	assert.Contains(t, buf.String(), `"level":"error"`)
+
+	assert.Equal(t, zerolog.TraceLevel, slogLevel(slog.LevelDebug-1))
+	assert.Equal(t, zerolog.DebugLevel, slogLevel(slog.LevelDebug))
+	assert.Equal(t, zerolog.ErrorLevel, slogLevel(slog.LevelError+4))
+}
+
+func TestSlog_WithContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf})
+
+	ctx, span := newTestTracer(t).Start(context.Background(), "test-span")
+	defer span.End()
+
+	logger.Slog().InfoContext(ctx, "traced")
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	assert.Equal(t, span.SpanContext().TraceID().String(), logData["trace_id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), logData["span_id"])
+}
+
+// flushBuffer records whether it was flushed or closed
+type flushBuffer struct {
+	bytes.Buffer
+	flushed bool
+}
+
+func (b *flushBuffer) Flush() error {
+	b.flushed = true
+	return nil
+}
+
+func TestFatal_ExitFunc(t *testing.T) {
+	var buf flushBuffer
+	exitCode := -1
+	var written string
+
+	logger := NewWithConfig(Config{
+		Output: &buf,
+		ExitFunc: func(code int) {
+			exitCode = code
+			written = buf.String()
+		},
+	})
+
+	logger.Fatal().Str("db", "orders").Msg("cannot connect")
+
+	assert.Equal(t, 1, exitCode)
+	assert.True(t, buf.flushed)
+	// The event is written before ExitFunc runs
+	assert.Contains(t, written, `"level":"fatal"`)
+	assert.Contains(t, written, `"message":"cannot connect"`)
+	assert.Contains(t, written, `"db":"orders"`)
+}
+
+// closeBuffer rejects writes once closed, like a closed file
+type closeBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closeBuffer) Write(p []byte) (int, error) {
+	if b.closed {
+		return 0, os.ErrClosed
+	}
+	return b.Buffer.Write(p)
+}
+
+func (b *closeBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestFatal_ExitFuncKeepsOutputOpen(t *testing.T) {
+	var buf closeBuffer
+	logger := NewWithConfig(Config{
+		Output:   &buf,
+		ExitFunc: func(int) {},
+	})
+
+	logger.Fatal().Msg("cannot connect")
+	logger.Info().Msg("still logging")
+
+	assert.False(t, buf.closed)
+	assert.Contains(t, buf.String(), `"message":"still logging"`)
+}
+
+func TestPanic_PanicFunc(t *testing.T) {
+	var buf bytes.Buffer
+	var recovered interface{}
+
+	logger := NewWithConfig(Config{
+		Output:    &buf,
+		PanicFunc: func(v interface{}) { recovered = v },
+	})
+
+	logger.Panic().Msg("invariant violated")
+
+	assert.Equal(t, "invariant violated", recovered)
+	assert.Contains(t, buf.String(), `"level":"panic"`)
+}
+
+func TestPanic_Default(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf})
+
+	assert.PanicsWithValue(t, "boom", func() {
+		logger.Panic().Msg("boom")
+	})
+	assert.Contains(t, buf.String(), `"message":"boom"`)
+}
+
+func TestFatal_Derived(t *testing.T) {
+	var buf bytes.Buffer
+	exited := false
+	logger := NewWithConfig(Config{
+		Output:   &buf,
+		ExitFunc: func(int) { exited = true },
+	})
+
+	ctx, span := newTestTracer(t).Start(context.Background(), "test-span")
+	defer span.End()
+
+	logger.WithContext(ctx).Fatal().Msg("traced fatal")
+	assert.True(t, exited)
+	assert.Contains(t, buf.String(), "trace_id")
+}
+
+func TestFatal_Disabled(t *testing.T) {
+	exited := false
+	logger := NewWithConfig(Config{
+		Output:    io.Discard,
+		LevelName: "disabled",
+		ExitFunc:  func(int) { exited = true },
+	})
+
+	// Like zerolog, a disabled fatal event still exits
+	logger.Fatal().Msg("not logged")
+	assert.True(t, exited)
+}
+
+// Frame and StackTrace mirror the pkg/errors types
+type Frame uintptr
+
+type StackTrace []Frame
+
+// stackError is a pkg/errors style error recording where it was created
+type stackError struct {
+	msg   string
+	stack []uintptr
+}
+
+func newStackError(msg string) error {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	return &stackError{msg: msg, stack: pcs[:n]}
+}
+
+func (e *stackError) Error() string { return e.msg }
+
+func (e *stackError) StackTrace() StackTrace {
+	frames := make(StackTrace, len(e.stack))
+	for i, pc := range e.stack {
+		frames[i] = Frame(pc)
+	}
+	return frames
+}
+
+func createOrder() error {
+	return newStackError("connection refused")
+}
+
+func TestErr_StackTrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf, StackTraceEnabled: true})
+
+	err := fmt.Errorf("create order: %w", createOrder())
+	logger.Err(err).Msg("request failed")
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	assert.Equal(t, "error", logData["level"])
+	assert.Equal(t, "create order: connection refused", logData["error"])
+	assert.Equal(t, []interface{}{"create order: connection refused", "connection refused"}, logData["errors"])
+
+	// The stack comes from where the inner error was created
+	stack, ok := logData["stack"].([]interface{})
+	require.True(t, ok)
+	require.NotEmpty(t, stack)
+	top := stack[0].(map[string]interface{})
+	assert.Contains(t, top["func"], "createOrder")
+	assert.Contains(t, top["source"], "logger_test.go")
+}
+
+func TestErr_RuntimeStack(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf, StackTraceEnabled: true})
+
+	err := fmt.Errorf("load config: %w", os.ErrNotExist)
+	logger.Err(err).Msg("startup failed")
+
+	var logData map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logData))
+	assert.Equal(t, []interface{}{"load config: file does not exist", "file does not exist"}, logData["errors"])
+
+	// Without a StackTrace method the stack is captured at the logging site
+	stack, ok := logData["stack"].([]interface{})
+	require.True(t, ok)
+	top := stack[0].(map[string]interface{})
+	assert.Contains(t, top["func"], "TestErr_RuntimeStack")
+}
+
+func TestErr_Joined(t *testing.T) {
+	assert.Equal(t,
+		[]string{"a\nb: c", "a", "b: c", "c"},
+		errorChain(errors.Join(errors.New("a"), fmt.Errorf("b: %w", errors.New("c")))),
+	)
+}
+
+func TestErr_Disabled(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Output: &buf})
+
+	logger.Err(fmt.Errorf("wrapped: %w", os.ErrNotExist)).Msg("failed")
+	assert.Contains(t, buf.String(), `"error":"wrapped: file does not exist"`)
+	assert.NotContains(t, buf.String(), "stack")
+	assert.NotContains(t, buf.String(), `"errors"`)
+
+	// A nil error logs at info level
+	buf.Reset()
+	logger.Err(nil).Msg("ok")
+	assert.Contains(t, buf.String(), `"level":"info"`)
+}
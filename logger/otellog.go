@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the OTel instrumentation scope for emitted records
+const instrumentationName = "github.com/davidsugianto/go-pkgs/logger"
+
+// WithOTelLogExporter returns a logger that also emits every event as an
+// OpenTelemetry log record through lp, with the event's fields as record
+// attributes. Regular output is unaffected.
+func (l *Logger) WithOTelLogExporter(lp otellog.LoggerProvider) *Logger {
+	exporter := &otelExporter{
+		logger:     lp.Logger(instrumentationName),
+		traceIDKey: l.traceIDKey,
+		spanIDKey:  l.spanIDKey,
+	}
+	out := exporter.tee(l.output())
+
+	derived := l.derive(l.zerolog().Output(out))
+	derived.out = out
+	derived.otel = exporter
+	return derived
+}
+
+// otelExporter converts JSON log events into OTel log records
+type otelExporter struct {
+	logger     otellog.Logger
+	traceIDKey string
+	spanIDKey  string
+}
+
+// tee returns a writer that writes events to out and exports them
+func (x *otelExporter) tee(out io.Writer) io.Writer {
+	return &otelWriter{out: out, exporter: x}
+}
+
+// emit decodes the JSON event p and emits it as an OTel log record. The
+// trace and span ID fields added by WithContext correlate it with the span.
+func (x *otelExporter) emit(level zerolog.Level, p []byte) {
+	var fields map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return
+	}
+
+	var record otellog.Record
+	record.SetTimestamp(time.Now())
+	record.SetSeverity(otelSeverity(level))
+	record.SetSeverityText(level.String())
+	if msg, ok := fields[zerolog.MessageFieldName].(string); ok {
+		record.SetBody(otellog.StringValue(msg))
+	}
+
+	var spanConfig trace.SpanContextConfig
+	for key, value := range fields {
+		switch key {
+		case zerolog.MessageFieldName, zerolog.LevelFieldName, zerolog.TimestampFieldName:
+			continue
+		case x.traceIDKey:
+			if s, ok := value.(string); ok {
+				spanConfig.TraceID, _ = trace.TraceIDFromHex(s)
+			}
+		case x.spanIDKey:
+			if s, ok := value.(string); ok {
+				spanConfig.SpanID, _ = trace.SpanIDFromHex(s)
+			}
+		}
+		record.AddAttributes(otellog.KeyValue{Key: key, Value: otelValue(value)})
+	}
+
+	ctx := context.Background()
+	if spanCtx := trace.NewSpanContext(spanConfig); spanCtx.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, spanCtx)
+	}
+	x.logger.Emit(ctx, record)
+}
+
+// otelWriter writes events to the regular output and exports them
+type otelWriter struct {
+	out      io.Writer
+	exporter *otelExporter
+}
+
+func (w *otelWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w *otelWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	w.exporter.emit(level, p)
+	if lw, ok := w.out.(zerolog.LevelWriter); ok {
+		return lw.WriteLevel(level, p)
+	}
+	return w.out.Write(p)
+}
+
+// Flush flushes the regular output so Fatal and Panic still flush it
+func (w *otelWriter) Flush() error {
+	flush(w.out, false)
+	return nil
+}
+
+// Close closes the regular output if it is a closer
+func (w *otelWriter) Close() error {
+	if c, ok := w.out.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// otelValue converts a decoded JSON value into an OTel log value
+func otelValue(v interface{}) otellog.Value {
+	switch v := v.(type) {
+	case string:
+		return otellog.StringValue(v)
+	case bool:
+		return otellog.BoolValue(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return otellog.Int64Value(i)
+		}
+		f, _ := v.Float64()
+		return otellog.Float64Value(f)
+	case []interface{}:
+		values := make([]otellog.Value, len(v))
+		for i, item := range v {
+			values[i] = otelValue(item)
+		}
+		return otellog.SliceValue(values...)
+	case map[string]interface{}:
+		kvs := make([]otellog.KeyValue, 0, len(v))
+		for key, item := range v {
+			kvs = append(kvs, otellog.KeyValue{Key: key, Value: otelValue(item)})
+		}
+		return otellog.MapValue(kvs...)
+	default:
+		return otellog.Value{}
+	}
+}
+
+// otelSeverity maps a zerolog level to the matching OTel severity
+func otelSeverity(level zerolog.Level) otellog.Severity {
+	switch level {
+	case zerolog.TraceLevel:
+		return otellog.SeverityTrace
+	case zerolog.DebugLevel:
+		return otellog.SeverityDebug
+	case zerolog.InfoLevel:
+		return otellog.SeverityInfo
+	case zerolog.WarnLevel:
+		return otellog.SeverityWarn
+	case zerolog.ErrorLevel:
+		return otellog.SeverityError
+	case zerolog.FatalLevel:
+		return otellog.SeverityFatal
+	case zerolog.PanicLevel:
+		return otellog.SeverityFatal4
+	default:
+		return otellog.SeverityUndefined
+	}
+}
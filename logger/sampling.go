@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// SamplingConfig rate-limits repetitive log lines: within each Period the
+// first BurstFirst events of a level are logged, then only one of every
+// ThenEvery (none if ThenEvery is 0)
+type SamplingConfig struct {
+	// BurstFirst is the number of events logged per period before sampling starts
+	BurstFirst int
+
+	// ThenEvery logs one of every ThenEvery events once the burst is used up
+	ThenEvery int
+
+	// Period is the window after which the burst resets (default: 1s)
+	Period time.Duration
+
+	// Levels lists the sampled levels (default: trace, debug and info).
+	// Events at other levels are always logged, so errors are never sampled
+	// out unless listed explicitly.
+	Levels []zerolog.Level
+}
+
+// sampler builds a zerolog sampler with independent counters per level
+func (c SamplingConfig) sampler() zerolog.Sampler {
+	period := c.Period
+	if period <= 0 {
+		period = time.Second
+	}
+	levels := c.Levels
+	if len(levels) == 0 {
+		levels = []zerolog.Level{zerolog.TraceLevel, zerolog.DebugLevel, zerolog.InfoLevel}
+	}
+
+	newSampler := func() zerolog.Sampler {
+		s := &zerolog.BurstSampler{
+			Burst:  uint32(c.BurstFirst),
+			Period: period,
+		}
+		if c.ThenEvery > 0 {
+			s.NextSampler = &zerolog.BasicSampler{N: uint32(c.ThenEvery)}
+		}
+		return s
+	}
+
+	ls := &zerolog.LevelSampler{}
+	for _, level := range levels {
+		switch level {
+		case zerolog.TraceLevel:
+			ls.TraceSampler = newSampler()
+		case zerolog.DebugLevel:
+			ls.DebugSampler = newSampler()
+		case zerolog.InfoLevel:
+			ls.InfoSampler = newSampler()
+		case zerolog.WarnLevel:
+			ls.WarnSampler = newSampler()
+		case zerolog.ErrorLevel:
+			ls.ErrorSampler = newSampler()
+		}
+	}
+	return ls
+}
@@ -0,0 +1,182 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+)
+
+// Slog returns a *slog.Logger that writes through this logger, keeping its
+// output, fields and level. Contexts passed to the slog *Context methods are
+// handled like WithContext, so trace IDs and hooks apply.
+func (l *Logger) Slog() *slog.Logger {
+	return slog.New(&slogHandler{logger: l})
+}
+
+// slogHandler implements slog.Handler on top of a Logger. Attributes and
+// groups added with WithAttrs/WithGroup are kept in order and rendered as
+// nested objects when a record is handled.
+type slogHandler struct {
+	logger *Logger
+	goas   []groupOrAttrs
+}
+
+// groupOrAttrs is either a group name or attributes added by WithAttrs
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+// slogLevel maps a slog level to the closest zerolog level; levels above
+// error stay at error so slog never exits or panics the program
+func slogLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return zerolog.TraceLevel
+	case level < slog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < slog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < slog.LevelError:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	zl := slogLevel(level)
+	return zl >= h.logger.GetLevel() && zl >= zerolog.GlobalLevel()
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	l := h.logger
+	if ctx != nil {
+		l = l.WithContext(ctx)
+	}
+	z := l.zerolog()
+	e := z.WithLevel(slogLevel(r.Level))
+	if e == nil {
+		return nil
+	}
+
+	// Split handler attributes at each group, then append the record's
+	// attributes to the innermost group
+	levels := [][]slog.Attr{nil}
+	var groups []string
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			groups = append(groups, goa.group)
+			levels = append(levels, nil)
+			continue
+		}
+		levels[len(levels)-1] = append(levels[len(levels)-1], goa.attrs...)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		levels[len(levels)-1] = append(levels[len(levels)-1], a)
+		return true
+	})
+
+	addSlogLevel(e, levels, groups, 0)
+	e.Msg(r.Message)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(groupOrAttrs{attrs: attrs})
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(groupOrAttrs{group: name})
+}
+
+func (h *slogHandler) with(goa groupOrAttrs) *slogHandler {
+	goas := make([]groupOrAttrs, len(h.goas), len(h.goas)+1)
+	copy(goas, h.goas)
+	return &slogHandler{logger: h.logger, goas: append(goas, goa)}
+}
+
+// addSlogLevel adds the attributes of levels[i] to e and nests the deeper
+// levels under their group names, omitting groups left empty. It reports
+// whether anything was added.
+func addSlogLevel(e *zerolog.Event, levels [][]slog.Attr, groups []string, i int) bool {
+	added := false
+	for _, a := range levels[i] {
+		if addSlogAttr(e, a) {
+			added = true
+		}
+	}
+	if i < len(groups) {
+		dict := zerolog.Dict()
+		if addSlogLevel(dict, levels, groups, i+1) {
+			e.Dict(groups[i], dict)
+			added = true
+		}
+	}
+	return added
+}
+
+// addSlogAttr adds a single attribute to e, reporting whether it was added
+func addSlogAttr(e *zerolog.Event, a slog.Attr) bool {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return false
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		e.Str(a.Key, a.Value.String())
+	case slog.KindInt64:
+		e.Int64(a.Key, a.Value.Int64())
+	case slog.KindUint64:
+		e.Uint64(a.Key, a.Value.Uint64())
+	case slog.KindFloat64:
+		e.Float64(a.Key, a.Value.Float64())
+	case slog.KindBool:
+		e.Bool(a.Key, a.Value.Bool())
+	case slog.KindDuration:
+		e.Dur(a.Key, a.Value.Duration())
+	case slog.KindTime:
+		e.Time(a.Key, a.Value.Time())
+	case slog.KindGroup:
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return false
+		}
+		// Groups without a key are inlined
+		if a.Key == "" {
+			added := false
+			for _, ga := range attrs {
+				if addSlogAttr(e, ga) {
+					added = true
+				}
+			}
+			return added
+		}
+		dict := zerolog.Dict()
+		added := false
+		for _, ga := range attrs {
+			if addSlogAttr(dict, ga) {
+				added = true
+			}
+		}
+		if !added {
+			return false
+		}
+		e.Dict(a.Key, dict)
+	default:
+		if err, ok := a.Value.Any().(error); ok {
+			e.AnErr(a.Key, err)
+		} else {
+			e.Interface(a.Key, a.Value.Any())
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+
+	"github.com/rs/zerolog"
+)
+
+// maxStackDepth bounds the number of frames captured at the logging site
+const maxStackDepth = 32
+
+// Err creates an error level log event for err (info level if err is nil),
+// like zerolog's Err. With Config.StackTraceEnabled it also adds an "errors"
+// array with the messages of the wrapped error chain and a "stack" array,
+// taken from the innermost error implementing StackTrace() (pkg/errors
+// style) or captured at the call site otherwise.
+func (l *Logger) Err(err error) *zerolog.Event {
+	z := l.zerolog()
+	e := z.Err(err)
+	if e == nil || err == nil || !l.stackTrace {
+		return e
+	}
+
+	e.Strs("errors", errorChain(err))
+
+	stack := errorStack(err)
+	if stack == nil {
+		pcs := make([]uintptr, maxStackDepth)
+		stack = framesOf(pcs[:runtime.Callers(2, pcs)])
+	}
+	return e.Interface("stack", stack)
+}
+
+// errorChain returns the messages of err and every error it wraps,
+// depth-first for errors wrapping several (errors.Join)
+func errorChain(err error) []string {
+	var chain []string
+	var walk func(error)
+	walk = func(err error) {
+		for err != nil {
+			chain = append(chain, err.Error())
+			if multi, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, inner := range multi.Unwrap() {
+					walk(inner)
+				}
+				return
+			}
+			err = errors.Unwrap(err)
+		}
+	}
+	walk(err)
+	return chain
+}
+
+// stackFrame is one entry of the "stack" field
+type stackFrame struct {
+	Func   string `json:"func"`
+	Source string `json:"source"`
+	Line   int    `json:"line"`
+}
+
+// errorStack returns the stack of the innermost error in the chain that
+// implements StackTrace(), or nil if none does. The method is found by
+// reflection so pkg/errors need not be a dependency; its frames are the
+// return addresses recorded by runtime.Callers.
+func errorStack(err error) []stackFrame {
+	var stack []stackFrame
+	for ; err != nil; err = errors.Unwrap(err) {
+		method := reflect.ValueOf(err).MethodByName("StackTrace")
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			continue
+		}
+		trace := method.Call(nil)[0]
+		if trace.Kind() != reflect.Slice || trace.Type().Elem().Kind() != reflect.Uintptr {
+			continue
+		}
+
+		pcs := make([]uintptr, trace.Len())
+		for i := range pcs {
+			pcs[i] = uintptr(trace.Index(i).Uint())
+		}
+		stack = framesOf(pcs)
+	}
+	return stack
+}
+
+// framesOf resolves program counters into stack frames
+func framesOf(pcs []uintptr) []stackFrame {
+	frames := runtime.CallersFrames(pcs)
+	var stack []stackFrame
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			stack = append(stack, stackFrame{Func: frame.Function, Source: frame.File, Line: frame.Line})
+		}
+		if !more {
+			return stack
+		}
+	}
+}
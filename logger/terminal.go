@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+// terminal starts an event at a fatal or panic level that calls done with
+// the message once the event has been written. Like zerolog, done is called
+// immediately if the level is disabled.
+func (l *Logger) terminal(level zerolog.Level, done func(msg string)) *zerolog.Event {
+	t := &terminator{out: l.output(), done: done}
+	z := l.zerolog().Output(t).Hook(t)
+	e := z.WithLevel(level)
+	if e == nil {
+		done("")
+	}
+	return e
+}
+
+// terminator writes an event to the real output and then calls done. It is
+// also a hook so it can remember the message, which the writer never sees
+// as a separate value.
+type terminator struct {
+	out  io.Writer
+	msg  string
+	done func(msg string)
+}
+
+func (t *terminator) Run(_ *zerolog.Event, _ zerolog.Level, msg string) {
+	t.msg = msg
+}
+
+func (t *terminator) Write(p []byte) (int, error) {
+	n, err := t.out.Write(p)
+	t.done(t.msg)
+	return n, err
+}
+
+func (t *terminator) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	var n int
+	var err error
+	if lw, ok := t.out.(zerolog.LevelWriter); ok {
+		n, err = lw.WriteLevel(level, p)
+	} else {
+		n, err = t.out.Write(p)
+	}
+	t.done(t.msg)
+	return n, err
+}
+
+// flush flushes buffered writers such as async or diode writers. Right
+// before os.Exit, writers without a Flush method are closed instead, as
+// zerolog does, since closing is their only way to flush.
+func flush(w io.Writer, closeWriter bool) {
+	if f, ok := w.(interface{ Flush() error }); ok {
+		f.Flush()
+		return
+	}
+	if closeWriter {
+		if c, ok := w.(io.Closer); ok {
+			c.Close()
+		}
+	}
+}
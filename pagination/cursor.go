@@ -0,0 +1,166 @@
+package pagination
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor indicates a cursor is malformed or has been tampered with
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorPagination describes a keyset page: up to Limit rows after the After
+// cursor, or before the Before cursor. After a query, SetPage fills in the
+// cursors for the adjacent pages.
+type CursorPagination struct {
+	Limit      int    `form:"limit" json:"limit"`
+	After      string `form:"after" json:"-"`
+	Before     string `form:"before" json:"-"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// SetDefault sets Limit to DefaultPageSize if it is below 1 and caps it at
+// DefaultMaxPageSize
+func (c *CursorPagination) SetDefault() CursorPagination {
+	if c.Limit < 1 {
+		c.Limit = DefaultPageSize
+	}
+	if c.Limit > DefaultMaxPageSize {
+		c.Limit = DefaultMaxPageSize
+	}
+	return *c
+}
+
+// Fetch returns the number of rows to query: one more than Limit, so SetPage
+// can tell whether another page exists
+func (c *CursorPagination) Fetch() int {
+	return c.Limit + 1
+}
+
+// Backward reports whether the page is read backwards from Before
+func (c *CursorPagination) Backward() bool {
+	return c.Before != ""
+}
+
+// SetPage trims the extra row fetched with Fetch from items and sets
+// NextCursor and PrevCursor from the first and last remaining rows. Items
+// must be in display order; when paging backwards the extra row is the first
+// one. id returns the key a cursor is built from.
+func SetPage[T any](c *CursorPagination, items []T, id func(T) any) []T {
+	more := len(items) > c.Limit
+	if more {
+		if c.Backward() {
+			items = items[len(items)-c.Limit:]
+		} else {
+			items = items[:c.Limit]
+		}
+	}
+
+	c.NextCursor, c.PrevCursor = "", ""
+	if len(items) == 0 {
+		return items
+	}
+	first, last := EncodeCursor(id(items[0])), EncodeCursor(id(items[len(items)-1]))
+	if c.Backward() {
+		c.NextCursor = last
+		if more {
+			c.PrevCursor = first
+		}
+	} else {
+		if more {
+			c.NextCursor = last
+		}
+		if c.After != "" {
+			c.PrevCursor = first
+		}
+	}
+	return items
+}
+
+// EncodeCursor returns an opaque, signed cursor for lastID, which must be
+// JSON-encodable. It panics if lastID cannot be encoded.
+func EncodeCursor(lastID any) string {
+	data, err := json.Marshal(lastID)
+	if err != nil {
+		panic("pagination: failed to encode cursor: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(append(data, sign(cursorDomain, data)...))
+}
+
+// DecodeCursor returns the ID encoded in token, with numbers decoded as
+// json.Number. An empty token returns nil. Malformed or tampered cursors
+// return ErrInvalidCursor.
+func DecodeCursor(token string) (any, error) {
+	data, err := cursorData(token)
+	if data == nil || err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var id any
+	if err := dec.Decode(&id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// DecodeCursorAs is like DecodeCursor but decodes the ID into T. An empty
+// token returns the zero value.
+func DecodeCursorAs[T any](token string) (T, error) {
+	var id T
+	err := Cursor(token).DecodeInto(&id)
+	return id, err
+}
+
+// Cursor is an opaque, signed keyset cursor as produced by EncodeCursor. It
+// converts to and from the string cursors in CursorPagination.
+type Cursor string
+
+// NewCursor returns the cursor for lastID, like EncodeCursor
+func NewCursor(lastID any) Cursor {
+	return Cursor(EncodeCursor(lastID))
+}
+
+// String returns the cursor token
+func (c Cursor) String() string {
+	return string(c)
+}
+
+// Decode returns the ID in the cursor, like DecodeCursor
+func (c Cursor) Decode() (any, error) {
+	return DecodeCursor(string(c))
+}
+
+// DecodeInto decodes the ID in the cursor into the value pointed to by v,
+// like DecodeCursorAs. An empty cursor leaves v unchanged.
+func (c Cursor) DecodeInto(v any) error {
+	data, err := cursorData(string(c))
+	if data == nil || err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// cursorData verifies token and returns its JSON payload, or nil if token is empty
+func cursorData(token string) ([]byte, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) <= signatureSize {
+		return nil, ErrInvalidCursor
+	}
+	data, sig := buf[:len(buf)-signatureSize], buf[len(buf)-signatureSize:]
+	if !hmac.Equal(sig, sign(cursorDomain, data)) {
+		return nil, ErrInvalidCursor
+	}
+	return data, nil
+}
@@ -0,0 +1,192 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		lastID any
+		want   any
+	}{
+		{name: "int", lastID: 42, want: json.Number("42")},
+		{name: "string", lastID: "user_01HF", want: "user_01HF"},
+		{name: "composite", lastID: []any{"2024-01-02T03:04:05Z", 7}, want: []any{"2024-01-02T03:04:05Z", json.Number("7")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := EncodeCursor(tt.lastID)
+			got, err := DecodeCursor(token)
+			if err != nil {
+				t.Fatalf("DecodeCursor(%q) error = %v", token, err)
+			}
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(tt.want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("DecodeCursor(EncodeCursor(%v)) = %v, want %v", tt.lastID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorAs(t *testing.T) {
+	id, err := DecodeCursorAs[int64](EncodeCursor(int64(1 << 40)))
+	if err != nil || id != 1<<40 {
+		t.Errorf("DecodeCursorAs[int64]() = %d, %v, want %d, nil", id, err, int64(1<<40))
+	}
+
+	id, err = DecodeCursorAs[int64]("")
+	if err != nil || id != 0 {
+		t.Errorf("DecodeCursorAs[int64](\"\") = %d, %v, want 0, nil", id, err)
+	}
+
+	if _, err := DecodeCursorAs[int64](EncodeCursor("abc")); err != ErrInvalidCursor {
+		t.Errorf("DecodeCursorAs[int64]() with string ID error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestCursor(t *testing.T) {
+	c := NewCursor(int64(7))
+	if c.String() != EncodeCursor(int64(7)) {
+		t.Errorf("NewCursor(7) = %q, want %q", c, EncodeCursor(int64(7)))
+	}
+
+	got, err := c.Decode()
+	if err != nil || got != json.Number("7") {
+		t.Errorf("Cursor.Decode() = %v, %v, want 7, nil", got, err)
+	}
+
+	var id int64
+	if err := c.DecodeInto(&id); err != nil || id != 7 {
+		t.Errorf("Cursor.DecodeInto() = %d, %v, want 7, nil", id, err)
+	}
+
+	id = 3
+	if err := Cursor("").DecodeInto(&id); err != nil || id != 3 {
+		t.Errorf("Cursor(\"\").DecodeInto() = %d, %v, want 3 unchanged, nil", id, err)
+	}
+
+	if _, err := Cursor("not-a-cursor").Decode(); err != ErrInvalidCursor {
+		t.Errorf("Cursor.Decode() with invalid token error = %v, want %v", err, ErrInvalidCursor)
+	}
+
+	p := CursorPagination{After: c.String()}
+	if _, err := Cursor(p.After).Decode(); err != nil {
+		t.Errorf("Cursor(After).Decode() error = %v", err)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	raw, _ := base64.RawURLEncoding.DecodeString(EncodeCursor(40))
+
+	tampered := append([]byte(nil), raw...)
+	tampered[0] = '5'
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "!!!"},
+		{name: "too short", token: base64.RawURLEncoding.EncodeToString(raw[:signatureSize])},
+		{name: "tampered id", token: base64.RawURLEncoding.EncodeToString(tampered)},
+		{name: "plain id", token: base64.RawURLEncoding.EncodeToString([]byte("40"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.token); err != ErrInvalidCursor {
+				t.Errorf("DecodeCursor(%q) error = %v, want %v", tt.token, err, ErrInvalidCursor)
+			}
+		})
+	}
+
+	if id, err := DecodeCursor(""); id != nil || err != nil {
+		t.Errorf("DecodeCursor(\"\") = %v, %v, want nil, nil", id, err)
+	}
+}
+
+func TestCursorAndPageTokenNotInterchangeable(t *testing.T) {
+	// 12345678 encodes to an 8-byte payload, the same size as a page token's
+	if _, err := DecodePageToken(EncodeCursor(12345678)); err != ErrInvalidPageToken {
+		t.Errorf("DecodePageToken(cursor) error = %v, want %v", err, ErrInvalidPageToken)
+	}
+	if _, err := DecodeCursor(EncodePageToken(3)); err != ErrInvalidCursor {
+		t.Errorf("DecodeCursor(page token) error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestCursorPaginationSetDefault(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{limit: 0, want: DefaultPageSize},
+		{limit: -5, want: DefaultPageSize},
+		{limit: 50, want: 50},
+		{limit: 500, want: DefaultMaxPageSize},
+	}
+
+	for _, tt := range tests {
+		c := CursorPagination{Limit: tt.limit}
+		if got := c.SetDefault().Limit; got != tt.want {
+			t.Errorf("SetDefault() with Limit %d = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestSetPage(t *testing.T) {
+	id := func(n int) any { return n }
+	decode := func(token string) int {
+		if token == "" {
+			return 0
+		}
+		n, err := DecodeCursorAs[int](token)
+		if err != nil {
+			t.Fatalf("DecodeCursorAs(%q) error = %v", token, err)
+		}
+		return n
+	}
+
+	tests := []struct {
+		name     string
+		page     CursorPagination
+		items    []int
+		want     []int
+		wantNext int
+		wantPrev int
+	}{
+		{name: "first page with more", page: CursorPagination{Limit: 3}, items: []int{1, 2, 3, 4}, want: []int{1, 2, 3}, wantNext: 3},
+		{name: "only page", page: CursorPagination{Limit: 3}, items: []int{1, 2}, want: []int{1, 2}},
+		{name: "middle page", page: CursorPagination{Limit: 2, After: EncodeCursor(2)}, items: []int{3, 4, 5}, want: []int{3, 4}, wantNext: 4, wantPrev: 3},
+		{name: "last page", page: CursorPagination{Limit: 2, After: EncodeCursor(4)}, items: []int{5}, want: []int{5}, wantPrev: 5},
+		{name: "backward with more", page: CursorPagination{Limit: 2, Before: EncodeCursor(5)}, items: []int{2, 3, 4}, want: []int{3, 4}, wantNext: 4, wantPrev: 3},
+		{name: "backward to start", page: CursorPagination{Limit: 2, Before: EncodeCursor(3)}, items: []int{1, 2}, want: []int{1, 2}, wantNext: 2},
+		{name: "empty", page: CursorPagination{Limit: 2, After: EncodeCursor(9)}, items: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.page
+			got := SetPage(&c, tt.items, id)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SetPage() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("SetPage() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+			if next := decode(c.NextCursor); next != tt.wantNext {
+				t.Errorf("NextCursor = %d, want %d", next, tt.wantNext)
+			}
+			if prev := decode(c.PrevCursor); prev != tt.wantPrev {
+				t.Errorf("PrevCursor = %d, want %d", prev, tt.wantPrev)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package pagination
+
+import "encoding/json"
+
+// Page is a page of items together with its pagination, ready to be written
+// as a response body
+type Page[T any] struct {
+	Items      []T        `json:"items"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// NewPage returns a Page holding items and p
+func NewPage[T any](items []T, p Pagination) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Page[T]{Items: items, Pagination: p}
+}
+
+// MarshalJSON encodes the page, writing nil Items as [] rather than null
+func (p Page[T]) MarshalJSON() ([]byte, error) {
+	type page Page[T]
+	if p.Items == nil {
+		p.Items = []T{}
+	}
+	return json.Marshal(page(p))
+}
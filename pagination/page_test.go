@@ -0,0 +1,45 @@
+package pagination
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewPage(t *testing.T) {
+	p := Pagination{Page: 1, PageSize: 2}
+	p.SetTotal(3)
+
+	tests := []struct {
+		name     string
+		page     Page[string]
+		expected string
+	}{
+		{
+			name:     "with items",
+			page:     NewPage([]string{"a", "b"}, p),
+			expected: `{"items":["a","b"],"pagination":{"page":1,"page_size":2,"total_data":3,"total_page":2}}`,
+		},
+		{
+			name:     "nil items",
+			page:     NewPage[string](nil, p),
+			expected: `{"items":[],"pagination":{"page":1,"page_size":2,"total_data":3,"total_page":2}}`,
+		},
+		{
+			name:     "zero value",
+			page:     Page[string]{},
+			expected: `{"items":[],"pagination":{"page":0,"page_size":0,"total_data":0,"total_page":0}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.page)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.expected)
+			}
+		})
+	}
+}
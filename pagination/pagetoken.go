@@ -0,0 +1,88 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"sync"
+)
+
+// ErrInvalidPageToken indicates a page token is malformed or has been tampered with
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+const (
+	offsetSize    = 8
+	signatureSize = 16
+)
+
+// Signature domains keep a token of one type from verifying as another
+const (
+	pageTokenDomain = "page:"
+	cursorDomain    = "cursor:"
+)
+
+var (
+	tokenKeyMu sync.RWMutex
+	tokenKey   = newTokenKey()
+)
+
+func newTokenKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic("pagination: failed to generate page token key: " + err.Error())
+	}
+	return key
+}
+
+// SetPageTokenKey sets the secret used to sign page tokens and cursors. By default a
+// random key is generated at startup, so tokens are only valid within the
+// process that issued them; set a shared key when running several instances.
+func SetPageTokenKey(key []byte) {
+	tokenKeyMu.Lock()
+	defer tokenKeyMu.Unlock()
+	tokenKey = append([]byte(nil), key...)
+}
+
+// EncodePageToken returns an opaque, signed token for offset
+func EncodePageToken(offset int) string {
+	buf := make([]byte, offsetSize, offsetSize+signatureSize)
+	binary.BigEndian.PutUint64(buf, uint64(offset))
+	buf = append(buf, sign(pageTokenDomain, buf[:offsetSize])...)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// DecodePageToken returns the offset encoded in token. An empty token means
+// offset 0. Malformed or tampered tokens return ErrInvalidPageToken.
+func DecodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) != offsetSize+signatureSize {
+		return 0, ErrInvalidPageToken
+	}
+	if !hmac.Equal(buf[offsetSize:], sign(pageTokenDomain, buf[:offsetSize])) {
+		return 0, ErrInvalidPageToken
+	}
+
+	offset := int(binary.BigEndian.Uint64(buf[:offsetSize]))
+	if offset < 0 {
+		return 0, ErrInvalidPageToken
+	}
+	return offset, nil
+}
+
+// sign returns the truncated HMAC of data in the given signature domain
+func sign(domain string, data []byte) []byte {
+	tokenKeyMu.RLock()
+	defer tokenKeyMu.RUnlock()
+
+	mac := hmac.New(sha256.New, tokenKey)
+	mac.Write([]byte(domain))
+	mac.Write(data)
+	return mac.Sum(nil)[:signatureSize]
+}
@@ -0,0 +1,74 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	for _, offset := range []int{0, 1, 20, 1000, 1 << 40} {
+		token := EncodePageToken(offset)
+		if token == "" {
+			t.Errorf("EncodePageToken(%d) returned empty token", offset)
+		}
+
+		got, err := DecodePageToken(token)
+		if err != nil {
+			t.Errorf("DecodePageToken(%q) error = %v", token, err)
+		}
+		if got != offset {
+			t.Errorf("DecodePageToken(EncodePageToken(%d)) = %d", offset, got)
+		}
+	}
+}
+
+func TestDecodePageTokenEmpty(t *testing.T) {
+	offset, err := DecodePageToken("")
+	if err != nil {
+		t.Errorf("DecodePageToken(\"\") error = %v", err)
+	}
+	if offset != 0 {
+		t.Errorf("DecodePageToken(\"\") = %d, want 0", offset)
+	}
+}
+
+func TestDecodePageTokenInvalid(t *testing.T) {
+	valid := EncodePageToken(40)
+	raw, _ := base64.RawURLEncoding.DecodeString(valid)
+
+	tampered := append([]byte(nil), raw...)
+	tampered[7] ^= 0x01
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "!!!"},
+		{name: "too short", token: base64.RawURLEncoding.EncodeToString(raw[:10])},
+		{name: "tampered offset", token: base64.RawURLEncoding.EncodeToString(tampered)},
+		{name: "plain offset", token: base64.RawURLEncoding.EncodeToString([]byte("40"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodePageToken(tt.token); err != ErrInvalidPageToken {
+				t.Errorf("DecodePageToken(%q) error = %v, want %v", tt.token, err, ErrInvalidPageToken)
+			}
+		})
+	}
+}
+
+func TestSetPageTokenKey(t *testing.T) {
+	SetPageTokenKey([]byte("key-a"))
+	token := EncodePageToken(20)
+
+	SetPageTokenKey([]byte("key-b"))
+	if _, err := DecodePageToken(token); err != ErrInvalidPageToken {
+		t.Errorf("Expected token signed with another key to be rejected, got %v", err)
+	}
+
+	SetPageTokenKey([]byte("key-a"))
+	if offset, err := DecodePageToken(token); err != nil || offset != 20 {
+		t.Errorf("DecodePageToken() = %d, %v, want 20, nil", offset, err)
+	}
+}
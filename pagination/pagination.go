@@ -1,5 +1,27 @@
 package pagination
 
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// DefaultPageSize is the page size SetDefault uses when none is given
+	DefaultPageSize = 20
+
+	// DefaultMaxPageSize is the largest page size SetDefault allows
+	DefaultMaxPageSize = 100
+)
+
+var (
+	// ErrInvalidPage indicates a page number below 1
+	ErrInvalidPage = errors.New("invalid page")
+
+	// ErrInvalidPageSize indicates a page size below 1 or above the maximum
+	ErrInvalidPageSize = errors.New("invalid page size")
+)
+
 type Pagination struct {
 	Page      int `form:"page" json:"page"`
 	PageSize  int `form:"page_size" json:"page_size"`
@@ -7,26 +29,121 @@ type Pagination struct {
 	TotalPage int `json:"total_page"`
 }
 
+// SetDefault sets Page to 1 if it is below 1, PageSize to DefaultPageSize if
+// it is below 1, and caps PageSize at DefaultMaxPageSize
 func (p *Pagination) SetDefault() Pagination {
-	if p.Page == 0 {
+	if p.Page < 1 {
 		p.Page = 1
 	}
-	if p.PageSize == 0 {
-		p.PageSize = 20
+	if p.PageSize < 1 {
+		p.PageSize = DefaultPageSize
+	}
+	if p.PageSize > DefaultMaxPageSize {
+		p.PageSize = DefaultMaxPageSize
 	}
 	return *p
 }
 
+// Validate reports whether Page is at least 1 and PageSize is within
+// [1, maxPageSize] (DefaultMaxPageSize if maxPageSize is not positive), for
+// rejecting bad input instead of clamping it
+func (p *Pagination) Validate(maxPageSize int) error {
+	if maxPageSize <= 0 {
+		maxPageSize = DefaultMaxPageSize
+	}
+	if p.Page < 1 {
+		return fmt.Errorf("%w: %d (must be at least 1)", ErrInvalidPage, p.Page)
+	}
+	if p.PageSize < 1 || p.PageSize > maxPageSize {
+		return fmt.Errorf("%w: %d (must be between 1 and %d)", ErrInvalidPageSize, p.PageSize, maxPageSize)
+	}
+	return nil
+}
+
 func (p *Pagination) Limit() int {
 	return p.PageSize
 }
 
+// Offset returns the number of rows to skip, never negative. An offset too
+// large for an int saturates at math.MaxInt.
 func (p *Pagination) Offset() int {
+	if p.Page < 1 || p.PageSize < 1 {
+		return 0
+	}
+	if p.Page-1 > math.MaxInt/p.PageSize {
+		return math.MaxInt
+	}
 	return (p.Page - 1) * p.PageSize
 }
 
+// SetTotal sets TotalData and computes TotalPage from PageSize. Call
+// SetDefault first: with a PageSize below 1 TotalPage is left at 0.
 func (p *Pagination) SetTotal(totalData int) Pagination {
 	p.TotalData = totalData
-	p.TotalPage = (totalData + p.PageSize - 1) / p.PageSize
+	p.TotalPage = 0
+	if p.PageSize > 0 {
+		p.TotalPage = (totalData + p.PageSize - 1) / p.PageSize
+	}
+	return *p
+}
+
+// SetTotalClamp is like SetTotal but also clamps Page to [1, TotalPage] so
+// Offset never points past the last page. With no data Page becomes 1.
+func (p *Pagination) SetTotalClamp(totalData int) Pagination {
+	p.SetTotal(totalData)
+	if p.Page > p.TotalPage {
+		p.Page = p.TotalPage
+	}
+	if p.Page < 1 {
+		p.Page = 1
+	}
 	return *p
 }
+
+// HasNext reports whether there is a page after Page
+func (p *Pagination) HasNext() bool {
+	return p.Page < p.TotalPage
+}
+
+// HasPrev reports whether there is a page before Page
+func (p *Pagination) HasPrev() bool {
+	return p.Page > 1
+}
+
+// NextPage returns the page after Page, or the last page if there is none
+// (1 if there are no pages)
+func (p *Pagination) NextPage() int {
+	if !p.HasNext() {
+		return max(p.TotalPage, 1)
+	}
+	return max(p.Page+1, 1)
+}
+
+// PrevPage returns the page before Page, or 1 if there is none
+func (p *Pagination) PrevPage() int {
+	if !p.HasPrev() {
+		return 1
+	}
+	return min(p.Page-1, max(p.TotalPage, 1))
+}
+
+// PageRange returns up to window consecutive page numbers centred on Page
+// and kept within [1, TotalPage], for rendering page links. It returns an
+// empty slice if there are no pages or window is not positive.
+func (p *Pagination) PageRange(window int) []int {
+	if window < 1 || p.TotalPage < 1 {
+		return []int{}
+	}
+	window = min(window, p.TotalPage)
+
+	current := min(max(p.Page, 1), p.TotalPage)
+	start := current - (window-1)/2
+	start = max(start, 1)
+	start = min(start, p.TotalPage-window+1)
+
+	pages := make([]int, window)
+	for i := range pages {
+		pages[i] = start + i
+	}
+	return pages
+}
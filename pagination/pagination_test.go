@@ -1,6 +1,10 @@
 package pagination
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"testing"
+)
 
 func TestSetDefault(t *testing.T) {
 	tests := []struct {
@@ -31,12 +35,22 @@ func TestSetDefault(t *testing.T) {
 		{
 			name:     "negative page",
 			p:        Pagination{Page: -1, PageSize: 10},
-			expected: Pagination{Page: -1, PageSize: 10},
+			expected: Pagination{Page: 1, PageSize: 10},
 		},
 		{
 			name:     "negative pageSize",
 			p:        Pagination{Page: 1, PageSize: -5},
-			expected: Pagination{Page: 1, PageSize: -5},
+			expected: Pagination{Page: 1, PageSize: 20},
+		},
+		{
+			name:     "pageSize above max",
+			p:        Pagination{Page: 2, PageSize: 1000},
+			expected: Pagination{Page: 2, PageSize: 100},
+		},
+		{
+			name:     "pageSize at max",
+			p:        Pagination{Page: 2, PageSize: 100},
+			expected: Pagination{Page: 2, PageSize: 100},
 		},
 	}
 
@@ -130,7 +144,22 @@ func TestOffset(t *testing.T) {
 		{
 			name:     "page 0, pageSize 10",
 			p:        Pagination{Page: 0, PageSize: 10},
-			expected: -10,
+			expected: 0,
+		},
+		{
+			name:     "negative page",
+			p:        Pagination{Page: -3, PageSize: 10},
+			expected: 0,
+		},
+		{
+			name:     "negative pageSize",
+			p:        Pagination{Page: 3, PageSize: -10},
+			expected: 0,
+		},
+		{
+			name:     "overflowing page saturates",
+			p:        Pagination{Page: math.MaxInt, PageSize: 20},
+			expected: math.MaxInt,
 		},
 	}
 
@@ -144,6 +173,61 @@ func TestOffset(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		p           Pagination
+		maxPageSize int
+		expected    error
+	}{
+		{
+			name:        "valid",
+			p:           Pagination{Page: 2, PageSize: 50},
+			maxPageSize: 50,
+			expected:    nil,
+		},
+		{
+			name:        "page zero",
+			p:           Pagination{Page: 0, PageSize: 10},
+			maxPageSize: 50,
+			expected:    ErrInvalidPage,
+		},
+		{
+			name:        "negative pageSize",
+			p:           Pagination{Page: 1, PageSize: -1},
+			maxPageSize: 50,
+			expected:    ErrInvalidPageSize,
+		},
+		{
+			name:        "pageSize above max",
+			p:           Pagination{Page: 1, PageSize: 51},
+			maxPageSize: 50,
+			expected:    ErrInvalidPageSize,
+		},
+		{
+			name:        "default max",
+			p:           Pagination{Page: 1, PageSize: 101},
+			maxPageSize: 0,
+			expected:    ErrInvalidPageSize,
+		},
+		{
+			name:        "within default max",
+			p:           Pagination{Page: 1, PageSize: 100},
+			maxPageSize: 0,
+			expected:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.Validate(tt.maxPageSize)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Validate(%d) = %v, want %v", tt.maxPageSize, err, tt.expected)
+			}
+		})
+	}
+}
+
 func TestSetTotal(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -222,6 +306,20 @@ func TestSetTotal(t *testing.T) {
 			expectedTotal: 61,
 			expectedPages: 4,
 		},
+		{
+			name:          "zero pageSize does not panic",
+			p:             Pagination{Page: 1, PageSize: 0},
+			totalData:     45,
+			expectedTotal: 45,
+			expectedPages: 0,
+		},
+		{
+			name:          "negative pageSize",
+			p:             Pagination{Page: 1, PageSize: -5},
+			totalData:     45,
+			expectedTotal: 45,
+			expectedPages: 0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -244,6 +342,65 @@ func TestSetTotal(t *testing.T) {
 	}
 }
 
+func TestSetTotalClamp(t *testing.T) {
+	tests := []struct {
+		name           string
+		p              Pagination
+		totalData      int
+		expectedPage   int
+		expectedPages  int
+		expectedOffset int
+	}{
+		{
+			name:           "page beyond last page",
+			p:              Pagination{Page: 10, PageSize: 20},
+			totalData:      45,
+			expectedPage:   3,
+			expectedPages:  3,
+			expectedOffset: 40,
+		},
+		{
+			name:           "page within range",
+			p:              Pagination{Page: 2, PageSize: 20},
+			totalData:      45,
+			expectedPage:   2,
+			expectedPages:  3,
+			expectedOffset: 20,
+		},
+		{
+			name:           "page below one",
+			p:              Pagination{Page: -1, PageSize: 20},
+			totalData:      45,
+			expectedPage:   1,
+			expectedPages:  3,
+			expectedOffset: 0,
+		},
+		{
+			name:           "zero total data",
+			p:              Pagination{Page: 5, PageSize: 20},
+			totalData:      0,
+			expectedPage:   1,
+			expectedPages:  0,
+			expectedOffset: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.p.SetTotalClamp(tt.totalData)
+			if result.Page != tt.expectedPage {
+				t.Errorf("SetTotalClamp() Page = %d, want %d", result.Page, tt.expectedPage)
+			}
+			if result.TotalPage != tt.expectedPages {
+				t.Errorf("SetTotalClamp() TotalPage = %d, want %d", result.TotalPage, tt.expectedPages)
+			}
+			if tt.p.Offset() != tt.expectedOffset {
+				t.Errorf("Offset() after SetTotalClamp() = %d, want %d", tt.p.Offset(), tt.expectedOffset)
+			}
+		})
+	}
+}
+
 func TestPaginationIntegration(t *testing.T) {
 	// Test a complete workflow
 	p := Pagination{Page: 0, PageSize: 0}
@@ -279,3 +436,106 @@ func TestPaginationIntegration(t *testing.T) {
 		t.Errorf("Integration: Limit() = %d, want 20", limit)
 	}
 }
+
+func TestNavigation(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        Pagination
+		hasNext  bool
+		hasPrev  bool
+		nextPage int
+		prevPage int
+	}{
+		{
+			name:     "first page",
+			p:        Pagination{Page: 1, TotalPage: 5},
+			hasNext:  true,
+			nextPage: 2,
+			prevPage: 1,
+		},
+		{
+			name:     "middle page",
+			p:        Pagination{Page: 3, TotalPage: 5},
+			hasNext:  true,
+			hasPrev:  true,
+			nextPage: 4,
+			prevPage: 2,
+		},
+		{
+			name:     "last page",
+			p:        Pagination{Page: 5, TotalPage: 5},
+			hasPrev:  true,
+			nextPage: 5,
+			prevPage: 4,
+		},
+		{
+			name:     "single page",
+			p:        Pagination{Page: 1, TotalPage: 1},
+			nextPage: 1,
+			prevPage: 1,
+		},
+		{
+			name:     "no pages",
+			p:        Pagination{Page: 1, TotalPage: 0},
+			nextPage: 1,
+			prevPage: 1,
+		},
+		{
+			name:     "page past the end",
+			p:        Pagination{Page: 9, TotalPage: 5},
+			hasPrev:  true,
+			nextPage: 5,
+			prevPage: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.HasNext(); got != tt.hasNext {
+				t.Errorf("HasNext() = %v, want %v", got, tt.hasNext)
+			}
+			if got := tt.p.HasPrev(); got != tt.hasPrev {
+				t.Errorf("HasPrev() = %v, want %v", got, tt.hasPrev)
+			}
+			if got := tt.p.NextPage(); got != tt.nextPage {
+				t.Errorf("NextPage() = %d, want %d", got, tt.nextPage)
+			}
+			if got := tt.p.PrevPage(); got != tt.prevPage {
+				t.Errorf("PrevPage() = %d, want %d", got, tt.prevPage)
+			}
+		})
+	}
+}
+
+func TestPageRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        Pagination
+		window   int
+		expected []int
+	}{
+		{name: "first page", p: Pagination{Page: 1, TotalPage: 10}, window: 5, expected: []int{1, 2, 3, 4, 5}},
+		{name: "middle page", p: Pagination{Page: 5, TotalPage: 10}, window: 5, expected: []int{3, 4, 5, 6, 7}},
+		{name: "even window", p: Pagination{Page: 5, TotalPage: 10}, window: 4, expected: []int{4, 5, 6, 7}},
+		{name: "last page", p: Pagination{Page: 10, TotalPage: 10}, window: 5, expected: []int{6, 7, 8, 9, 10}},
+		{name: "window larger than total", p: Pagination{Page: 2, TotalPage: 3}, window: 5, expected: []int{1, 2, 3}},
+		{name: "single page", p: Pagination{Page: 1, TotalPage: 1}, window: 5, expected: []int{1}},
+		{name: "no pages", p: Pagination{Page: 1, TotalPage: 0}, window: 5, expected: []int{}},
+		{name: "zero window", p: Pagination{Page: 1, TotalPage: 10}, window: 0, expected: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.p.PageRange(tt.window)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("PageRange(%d) = %v, want %v", tt.window, result, tt.expected)
+			}
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("PageRange(%d) = %v, want %v", tt.window, result, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
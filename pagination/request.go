@@ -0,0 +1,41 @@
+package pagination
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// MaxRequestPage is the largest page FromRequest accepts; larger values are
+// clamped so user input cannot produce huge or overflowing offsets
+const MaxRequestPage = 1_000_000
+
+// FromRequest reads the page and page_size (or limit) query parameters of r
+// and applies SetDefault. Missing or malformed values fall back to the
+// defaults rather than failing the request, and page is capped at
+// MaxRequestPage.
+func FromRequest(r *http.Request) Pagination {
+	q := r.URL.Query()
+
+	pageSize := q.Get("page_size")
+	if pageSize == "" {
+		pageSize = q.Get("limit")
+	}
+
+	p := Pagination{
+		Page:     queryInt(q.Get("page")),
+		PageSize: queryInt(pageSize),
+	}
+	if p.Page > MaxRequestPage {
+		p.Page = MaxRequestPage
+	}
+	return p.SetDefault()
+}
+
+// queryInt parses a query value, returning 0 if it is not an integer
+func queryInt(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,41 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected Pagination
+	}{
+		{name: "missing params", query: "", expected: Pagination{Page: 1, PageSize: 20}},
+		{name: "empty params", query: "page=&page_size=", expected: Pagination{Page: 1, PageSize: 20}},
+		{name: "valid params", query: "page=3&page_size=50", expected: Pagination{Page: 3, PageSize: 50}},
+		{name: "non-numeric params", query: "page=abc&page_size=1.5", expected: Pagination{Page: 1, PageSize: 20}},
+		{name: "negative params", query: "page=-2&page_size=-10", expected: Pagination{Page: 1, PageSize: 20}},
+		{name: "pageSize above max", query: "page=2&page_size=1000", expected: Pagination{Page: 2, PageSize: 100}},
+		{name: "limit alias", query: "page=2&limit=30", expected: Pagination{Page: 2, PageSize: 30}},
+		{name: "page_size wins over limit", query: "page_size=10&limit=30", expected: Pagination{Page: 1, PageSize: 10}},
+		{name: "malformed page_size ignores limit", query: "page_size=x&limit=30", expected: Pagination{Page: 1, PageSize: 20}},
+		{name: "huge page is clamped", query: "page=9223372036854775807&page_size=20", expected: Pagination{Page: MaxRequestPage, PageSize: 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/users?"+tt.query, nil)
+			result := FromRequest(r)
+			if result.Page != tt.expected.Page {
+				t.Errorf("FromRequest() Page = %d, want %d", result.Page, tt.expected.Page)
+			}
+			if result.PageSize != tt.expected.PageSize {
+				t.Errorf("FromRequest() PageSize = %d, want %d", result.PageSize, tt.expected.PageSize)
+			}
+			if result.Offset() < 0 {
+				t.Errorf("FromRequest() Offset = %d, want non-negative", result.Offset())
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// GetOrSet returns the cached value of key, or on a miss calls loader,
+// stores its result with ttl and returns it. Concurrent misses for the same
+// key share a single loader call, which runs with the first caller's ctx.
+func (c *Client) GetOrSet(ctx context.Context, key string, ttl time.Duration, loader func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	val, err := c.GetBytes(ctx, key)
+	if err != ErrKeyNotFound {
+		return val, err
+	}
+
+	result, err, _ := c.loads.Do(key, func() (interface{}, error) {
+		data, err := loader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Set(ctx, key, data, ttl); err != nil {
+			return nil, err
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]byte), nil
+}
+
+// GetOrSetJSON is like GetOrSet for JSON-serialized values of type T
+func GetOrSetJSON[T any](ctx context.Context, c *Client, key string, ttl time.Duration, loader func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+
+	data, err := c.GetOrSet(ctx, key, ttl, func(ctx context.Context) ([]byte, error) {
+		value, err := loader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		jsonData, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return jsonData, nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrSet(t *testing.T) {
+	client := newTestClient(t)
+	client.Delete(testCtx, "test:getorset")
+	defer client.Delete(testCtx, "test:getorset")
+
+	var loads atomic.Int32
+	loader := func(ctx context.Context) ([]byte, error) {
+		loads.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		return []byte("loaded"), nil
+	}
+
+	// Concurrent misses call the loader once
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := client.GetOrSet(testCtx, "test:getorset", time.Minute, loader)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("loaded"), val)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), loads.Load())
+
+	// Hits don't call the loader
+	val, err := client.GetOrSet(testCtx, "test:getorset", time.Minute, loader)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("loaded"), val)
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestGetOrSetLoaderError(t *testing.T) {
+	client := newTestClient(t)
+	client.Delete(testCtx, "test:getorset:err")
+
+	loadErr := errors.New("database down")
+	_, err := client.GetOrSet(testCtx, "test:getorset:err", time.Minute, func(ctx context.Context) ([]byte, error) {
+		return nil, loadErr
+	})
+	assert.Equal(t, loadErr, err)
+
+	exists, err := client.Exists(testCtx, "test:getorset:err")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestGetOrSetJSON(t *testing.T) {
+	client := newTestClient(t)
+	client.Delete(testCtx, "test:getorset:json")
+	defer client.Delete(testCtx, "test:getorset:json")
+
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	loader := func(ctx context.Context) (user, error) {
+		return user{ID: 1, Name: "John"}, nil
+	}
+
+	u, err := GetOrSetJSON(testCtx, client, "test:getorset:json", time.Minute, loader)
+	require.NoError(t, err)
+	assert.Equal(t, user{ID: 1, Name: "John"}, u)
+
+	var cached user
+	require.NoError(t, client.GetJSON(testCtx, "test:getorset:json", &cached))
+	assert.Equal(t, u, cached)
+}
@@ -299,14 +299,22 @@ func demoSortedSetOperations(ctx context.Context, client *redis.Client) {
 	}
 	fmt.Println("Added scores to leaderboard")
 
-	// Get top players
-	top, err := client.ZRange(ctx, "leaderboard", 0, -1)
+	// Get top players, highest score first
+	top, err := client.ZRevRange(ctx, "leaderboard", 0, -1)
 	if err != nil {
 		log.Printf("Error: %v", err)
 		return
 	}
 	fmt.Printf("Leaderboard: %v\n", top)
 
+	// Get a player's position
+	rank, err := client.ZRevRank(ctx, "leaderboard", "Charlie")
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return
+	}
+	fmt.Printf("Charlie is ranked #%d\n", rank+1)
+
 	client.Delete(ctx, "leaderboard")
 }
 
@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"time"
+)
+
+// healthCheckTimeout bounds the ping and INFO calls made by Health
+const healthCheckTimeout = 2 * time.Second
+
+// HealthStatus is a point-in-time view of the connection, suitable for
+// returning from a health check endpoint
+type HealthStatus struct {
+	Connected bool          `json:"connected"`
+	Latency   time.Duration `json:"latency"`
+	Version   string        `json:"version,omitempty"`
+	Error     string        `json:"error,omitempty"`
+
+	Hits       uint32 `json:"hits"`
+	Misses     uint32 `json:"misses"`
+	Timeouts   uint32 `json:"timeouts"`
+	TotalConns uint32 `json:"total_conns"`
+	IdleConns  uint32 `json:"idle_conns"`
+	StaleConns uint32 `json:"stale_conns"`
+}
+
+// Health pings the server with a short timeout and reports connectivity,
+// round-trip latency, server version and connection pool counters
+func (c *Client) Health(ctx context.Context) HealthStatus {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	stats := c.Client.PoolStats()
+	status := HealthStatus{
+		Hits:       stats.Hits,
+		Misses:     stats.Misses,
+		Timeouts:   stats.Timeouts,
+		TotalConns: stats.TotalConns,
+		IdleConns:  stats.IdleConns,
+		StaleConns: stats.StaleConns,
+	}
+
+	start := time.Now()
+	if err := c.Client.Ping(ctx).Err(); err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Latency = time.Since(start)
+	status.Connected = true
+
+	if info, err := c.Client.Info(ctx, "server").Result(); err == nil {
+		status.Version = parseVersion(info)
+	}
+	return status
+}
+
+// parseVersion extracts redis_version from the output of INFO server
+func parseVersion(info string) string {
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		if version, ok := strings.CutPrefix(scanner.Text(), "redis_version:"); ok {
+			return strings.TrimSpace(version)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,34 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealth(t *testing.T) {
+	client := newTestClient(t)
+
+	status := client.Health(testCtx)
+	assert.True(t, status.Connected)
+	assert.Empty(t, status.Error)
+	assert.Greater(t, status.Latency, time.Duration(0))
+	assert.NotEmpty(t, status.Version)
+}
+
+func TestHealthDisconnected(t *testing.T) {
+	client := New("localhost:1")
+	defer client.Close()
+
+	status := client.Health(testCtx)
+	assert.False(t, status.Connected)
+	assert.NotEmpty(t, status.Error)
+	assert.Zero(t, status.Latency)
+}
+
+func TestParseVersion(t *testing.T) {
+	info := "# Server\r\nredis_version:7.2.4\r\nredis_git_sha1:00000000\r\n"
+	assert.Equal(t, "7.2.4", parseVersion(info))
+	assert.Equal(t, "", parseVersion("# Server\r\n"))
+}
@@ -0,0 +1,35 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/davidsugianto/go-pkgs/httpclient"
+)
+
+type httpCache struct {
+	client *Client
+	ttl    time.Duration
+}
+
+// AsHTTPCache returns an httpclient.Cache that stores entries in Redis,
+// expiring them after ttl
+func (c *Client) AsHTTPCache(ttl time.Duration) httpclient.Cache {
+	return &httpCache{client: c, ttl: ttl}
+}
+
+func (h *httpCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := h.client.GetBytes(ctx, key)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func (h *httpCache) Set(ctx context.Context, key string, value []byte) error {
+	return h.client.Set(ctx, key, value, h.ttl)
+}
@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsHTTPCache(t *testing.T) {
+	client := newTestClient(t)
+	cache := client.AsHTTPCache(10 * time.Second)
+	defer client.Delete(testCtx, "test:httpcache")
+
+	_, found, err := cache.Get(testCtx, "test:httpcache")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	body := []byte(`{"id":1,"name":"John"}`)
+	require.NoError(t, cache.Set(testCtx, "test:httpcache", body))
+
+	cached, found, err := cache.Get(testCtx, "test:httpcache")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, body, cached)
+
+	ttl, err := client.TTL(testCtx, "test:httpcache")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+}
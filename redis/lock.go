@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrLockNotAcquired indicates the lock is held by someone else
+	ErrLockNotAcquired = errors.New("lock not acquired")
+
+	// ErrLockNotHeld indicates the lock expired or was taken by another holder
+	ErrLockNotHeld = errors.New("lock not held")
+)
+
+// unlockScript deletes the lock only if it still holds our token
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// refreshScript extends the lock only if it still holds our token
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// Lock is a distributed lock held until Unlock or until its TTL expires
+type Lock struct {
+	client *Client
+	key    string
+	token  string
+}
+
+// Lock acquires a lock on key that expires after ttl (returns ErrLockNotAcquired if already held)
+func (c *Client) Lock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	ok, err := c.SetNX(ctx, key, token, ttl)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLockNotAcquired
+	}
+	return &Lock{client: c, key: key, token: token}, nil
+}
+
+// Key returns the locked key
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Unlock releases the lock if it is still ours (returns ErrLockNotHeld otherwise)
+func (l *Lock) Unlock(ctx context.Context) error {
+	n, err := unlockScript.Run(ctx, l.client.Client, []string{l.client.key(l.key)}, l.token).Int64()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Refresh extends the lock to expire ttl from now (returns ErrLockNotHeld if it is no longer ours)
+func (l *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	n, err := refreshScript.Run(ctx, l.client.Client, []string{l.client.key(l.key)}, l.token, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	client := newTestClient(t)
+	defer client.Delete(testCtx, "test:lock")
+
+	lock, err := client.Lock(testCtx, "test:lock", 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "test:lock", lock.Key())
+
+	// Already held
+	_, err = client.Lock(testCtx, "test:lock", 10*time.Second)
+	assert.Equal(t, ErrLockNotAcquired, err)
+
+	// Refresh
+	require.NoError(t, lock.Refresh(testCtx, time.Minute))
+	ttl, err := client.TTL(testCtx, "test:lock")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 10*time.Second)
+
+	// Unlock
+	require.NoError(t, lock.Unlock(testCtx))
+	exists, err := client.Exists(testCtx, "test:lock")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.Equal(t, ErrLockNotHeld, lock.Unlock(testCtx))
+	assert.Equal(t, ErrLockNotHeld, lock.Refresh(testCtx, time.Minute))
+}
+
+func TestLockUnlockAfterExpiry(t *testing.T) {
+	client := newTestClient(t)
+	defer client.Delete(testCtx, "test:lock:expiry")
+
+	lock, err := client.Lock(testCtx, "test:lock:expiry", 50*time.Millisecond)
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Another holder acquires the expired lock
+	other, err := client.Lock(testCtx, "test:lock:expiry", 10*time.Second)
+	require.NoError(t, err)
+
+	// The original holder must not release it
+	assert.Equal(t, ErrLockNotHeld, lock.Unlock(testCtx))
+	exists, err := client.Exists(testCtx, "test:lock:expiry")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, other.Unlock(testCtx))
+}
@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Pipeline buffers commands and sends them in a single round trip on Exec.
+// The typed helpers mirror the Client ones; any other go-redis command can be
+// queued through the embedded Pipeliner.
+type Pipeline struct {
+	redis.Pipeliner
+	client *Client
+	err    error
+}
+
+// Pipeline creates a new command pipeline
+func (c *Client) Pipeline() *Pipeline {
+	return &Pipeline{Pipeliner: c.Client.Pipeline(), client: c}
+}
+
+// Set queues a key-value pair with expiration
+func (p *Pipeline) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) {
+	p.Pipeliner.Set(ctx, p.client.key(key), value, expiration)
+}
+
+// SetJSON queues a JSON-serialized value with expiration
+func (p *Pipeline) SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		if p.err == nil {
+			p.err = fmt.Errorf("failed to marshal value for %s: %w", key, err)
+		}
+		return
+	}
+	p.Pipeliner.Set(ctx, p.client.key(key), data, expiration)
+}
+
+// Delete queues removal of one or more keys
+func (p *Pipeline) Delete(ctx context.Context, keys ...string) {
+	p.Pipeliner.Del(ctx, p.client.prefixKeys(keys)...)
+}
+
+// Expire queues an expiration time on a key
+func (p *Pipeline) Expire(ctx context.Context, key string, expiration time.Duration) {
+	p.Pipeliner.Expire(ctx, p.client.key(key), expiration)
+}
+
+// Increment queues an increment of a key by value
+func (p *Pipeline) Increment(ctx context.Context, key string, value int64) {
+	p.Pipeliner.IncrBy(ctx, p.client.key(key), value)
+}
+
+// HSet queues setting a field in a hash
+func (p *Pipeline) HSet(ctx context.Context, key string, field string, value interface{}) {
+	p.Pipeliner.HSet(ctx, p.client.key(key), field, value)
+}
+
+// HMSet queues setting multiple fields in a hash
+func (p *Pipeline) HMSet(ctx context.Context, key string, pairs ...interface{}) {
+	p.Pipeliner.HMSet(ctx, p.client.key(key), pairs...)
+}
+
+// LPush queues pushing values to the head of a list
+func (p *Pipeline) LPush(ctx context.Context, key string, values ...interface{}) {
+	p.Pipeliner.LPush(ctx, p.client.key(key), values...)
+}
+
+// RPush queues pushing values to the tail of a list
+func (p *Pipeline) RPush(ctx context.Context, key string, values ...interface{}) {
+	p.Pipeliner.RPush(ctx, p.client.key(key), values...)
+}
+
+// SAdd queues adding members to a set
+func (p *Pipeline) SAdd(ctx context.Context, key string, members ...interface{}) {
+	p.Pipeliner.SAdd(ctx, p.client.key(key), members...)
+}
+
+// ZAdd queues adding members with scores to a sorted set
+func (p *Pipeline) ZAdd(ctx context.Context, key string, members ...redis.Z) {
+	p.Pipeliner.ZAdd(ctx, p.client.key(key), members...)
+}
+
+// Exec sends all queued commands in one round trip. It returns one error per
+// command in queue order (nil on success) and the first error encountered.
+// If a value failed to serialize nothing is sent.
+func (p *Pipeline) Exec(ctx context.Context) ([]error, error) {
+	if p.err != nil {
+		p.Pipeliner.Discard()
+		return nil, p.err
+	}
+
+	cmds, err := p.Pipeliner.Exec(ctx)
+	errs := make([]error, len(cmds))
+	for i, cmd := range cmds {
+		errs[i] = cmd.Err()
+	}
+	return errs, err
+}
@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+	client := newTestClient(t)
+	defer client.Delete(testCtx, "test:pipe:str", "test:pipe:json", "test:pipe:hash", "test:pipe:set")
+
+	pipe := client.Pipeline()
+	pipe.Set(testCtx, "test:pipe:str", "value", time.Minute)
+	pipe.SetJSON(testCtx, "test:pipe:json", map[string]int{"id": 1}, time.Minute)
+	pipe.HSet(testCtx, "test:pipe:hash", "field", "value")
+	pipe.SAdd(testCtx, "test:pipe:set", "a", "b")
+	pipe.Increment(testCtx, "test:pipe:str", 1) // fails: not an integer
+
+	errs, err := pipe.Exec(testCtx)
+	assert.Error(t, err)
+	require.Len(t, errs, 5)
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, errs[i], "command %d", i)
+	}
+	assert.Error(t, errs[4])
+
+	val, err := client.Get(testCtx, "test:pipe:str")
+	require.NoError(t, err)
+	assert.Equal(t, "value", val)
+
+	var data map[string]int
+	require.NoError(t, client.GetJSON(testCtx, "test:pipe:json", &data))
+	assert.Equal(t, 1, data["id"])
+
+	members, err := client.SMembers(testCtx, "test:pipe:set")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+}
+
+func TestPipelineMarshalError(t *testing.T) {
+	client := New("localhost:6379")
+	defer client.Close()
+
+	pipe := client.Pipeline()
+	pipe.SetJSON(testCtx, "test:pipe:bad", make(chan int), time.Minute)
+
+	_, err := pipe.Exec(testCtx)
+	assert.ErrorContains(t, err, "failed to marshal value for test:pipe:bad")
+}
+
+func benchmarkClient(b *testing.B) *Client {
+	b.Helper()
+	client := New("localhost:6379")
+	b.Cleanup(func() { client.Close() })
+
+	if err := client.Ping(testCtx); err != nil {
+		b.Skip("Redis not available, skipping benchmark")
+	}
+	return client
+}
+
+// BenchmarkSet1000 sets 1000 keys with one round trip each
+func BenchmarkSet1000(b *testing.B) {
+	client := benchmarkClient(b)
+
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 1000; j++ {
+			client.Set(testCtx, fmt.Sprintf("bench:set:%d", j), j, time.Minute)
+		}
+	}
+}
+
+// BenchmarkPipelineSet1000 sets 1000 keys in a single round trip
+func BenchmarkPipelineSet1000(b *testing.B) {
+	client := benchmarkClient(b)
+
+	for i := 0; i < b.N; i++ {
+		pipe := client.Pipeline()
+		for j := 0; j < 1000; j++ {
+			pipe.Set(testCtx, fmt.Sprintf("bench:set:%d", j), j, time.Minute)
+		}
+		if _, err := pipe.Exec(testCtx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyPrefixHelpers(t *testing.T) {
+	client := New("localhost:6379", WithKeyPrefix("svc:"))
+	defer client.Close()
+
+	assert.Equal(t, "svc:user:1", client.key("user:1"))
+	assert.Equal(t, []string{"svc:a", "svc:b"}, client.prefixKeys([]string{"a", "b"}))
+	assert.Equal(t, "svc:user:*", client.pattern("user:*"))
+	assert.Equal(t, "svc:*", client.pattern(""))
+	assert.Equal(t, []string{"a", "b"}, client.trimKeys([]string{"svc:a", "svc:b"}))
+
+	assert.Equal(t, []interface{}{"svc:a", 1, "svc:b", 2}, client.prefixPairs([]interface{}{"a", 1, "b", 2}))
+	assert.Equal(t,
+		[]interface{}{map[string]interface{}{"svc:a": 1}},
+		client.prefixPairs([]interface{}{map[string]interface{}{"a": 1}}),
+	)
+	assert.Equal(t,
+		[]interface{}{map[string]string{"svc:a": "1"}},
+		client.prefixPairs([]interface{}{map[string]string{"a": "1"}}),
+	)
+
+	// Without a prefix everything passes through unchanged
+	plain := New("localhost:6379")
+	defer plain.Close()
+	assert.Equal(t, "user:1", plain.key("user:1"))
+	assert.Equal(t, "", plain.pattern(""))
+	assert.Equal(t, []interface{}{"a", 1}, plain.prefixPairs([]interface{}{"a", 1}))
+}
+
+func TestWithKeyPrefix(t *testing.T) {
+	raw := newTestClient(t)
+	client := New("localhost:6379", WithKeyPrefix("test:prefix:"))
+	defer client.Close()
+	defer raw.Delete(testCtx, "test:prefix:a", "test:prefix:b", "test:prefix:c", "test:prefix:list")
+
+	// Single-key operations are stored under the prefixed key
+	require.NoError(t, client.Set(testCtx, "a", "1", 10*time.Second))
+	val, err := raw.Get(testCtx, "test:prefix:a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", val)
+
+	val, err = client.Get(testCtx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", val)
+
+	// Multi-key operations prefix every key
+	require.NoError(t, client.MSet(testCtx, "b", "2", "c", "3"))
+	values, err := client.MGet(testCtx, "a", "b", "c")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"1", "2", "3"}, values)
+
+	exists, err := raw.Exists(testCtx, "test:prefix:b", "test:prefix:c")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// Keys and Scan return un-prefixed keys
+	keys, err := client.Keys(testCtx, "*")
+	require.NoError(t, err)
+	sort.Strings(keys)
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+
+	var scanned []string
+	require.NoError(t, client.ScanEach(testCtx, "", 100, func(key string) error {
+		scanned = append(scanned, key)
+		return nil
+	}))
+	sort.Strings(scanned)
+	assert.Equal(t, []string{"a", "b", "c"}, scanned)
+
+	// Pipelines and locks share the prefix
+	pipe := client.Pipeline()
+	pipe.RPush(testCtx, "list", "x")
+	_, err = pipe.Exec(testCtx)
+	require.NoError(t, err)
+	n, err := raw.LLen(testCtx, "test:prefix:list")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	lock, err := client.Lock(testCtx, "lock", 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "lock", lock.Key())
+	require.NoError(t, lock.Refresh(testCtx, time.Minute))
+	require.NoError(t, lock.Unlock(testCtx))
+
+	require.NoError(t, client.Delete(testCtx, "a", "b", "c"))
+	exists, err = raw.Exists(testCtx, "test:prefix:a")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// resubscribeDelay is how long SubscribeChan waits before receiving again
+// after a connection error
+const resubscribeDelay = 500 * time.Millisecond
+
+// Message is a Pub/Sub message delivered by SubscribeChan
+type Message struct {
+	Channel string
+	Payload string
+}
+
+// SubscribeChan subscribes to channels and delivers their messages on the
+// returned channel until ctx is cancelled. Transient connection errors are
+// retried and the subscription is restored on reconnect. Callers must keep
+// draining the channel; a slow reader blocks delivery of further messages.
+func (c *Client) SubscribeChan(ctx context.Context, channels ...string) (<-chan Message, error) {
+	pubsub := c.Client.Subscribe(ctx, channels...)
+
+	// Wait for the subscription confirmation so setup errors surface here
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	// Receive only honours a ctx deadline, not cancellation, so close the
+	// subscription on ctx.Done() to unblock a pending read
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+		pubsub.Close()
+	}()
+
+	out := make(chan Message)
+	go func() {
+		defer close(out)
+		defer close(stop)
+
+		for {
+			msg, err := pubsub.Receive(ctx)
+			if ctx.Err() != nil {
+				// The error, if any, is from closing the subscription
+				return
+			}
+			if err != nil {
+				// go-redis reconnects and resubscribes on the next Receive
+				select {
+				case <-time.After(resubscribeDelay):
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			m, ok := msg.(*redis.Message)
+			if !ok {
+				continue
+			}
+			select {
+			case out <- Message{Channel: m.Channel, Payload: m.Payload}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
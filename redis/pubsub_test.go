@@ -0,0 +1,134 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeChan(t *testing.T) {
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithCancel(testCtx)
+	defer cancel()
+
+	messages, err := client.SubscribeChan(ctx, "test:pubsub:a", "test:pubsub:b")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Publish(testCtx, "test:pubsub:a", "hello"))
+	require.NoError(t, client.Publish(testCtx, "test:pubsub:b", "world"))
+
+	for _, want := range []Message{
+		{Channel: "test:pubsub:a", Payload: "hello"},
+		{Channel: "test:pubsub:b", Payload: "world"},
+	} {
+		select {
+		case msg := <-messages:
+			assert.Equal(t, want, msg)
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for message")
+		}
+	}
+
+	// Cancelling the context closes the channel
+	cancel()
+	select {
+	case _, ok := <-messages:
+		assert.False(t, ok)
+	case <-time.After(2 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+// fakeSubscribeServer is a minimal RESP server that confirms SUBSCRIBE
+// commands and then never sends a message, so readers stay blocked
+func fakeSubscribeServer(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeSubscribe(conn)
+		}
+	}()
+	return lis.Addr().String()
+}
+
+func serveFakeSubscribe(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			fmt.Fprint(conn, "+PONG\r\n")
+		case "SUBSCRIBE":
+			for i, channel := range args[1:] {
+				fmt.Fprintf(conn, "*3\r\n$9\r\nsubscribe\r\n$%d\r\n%s\r\n:%d\r\n", len(channel), channel, i+1)
+			}
+		default:
+			fmt.Fprint(conn, "-ERR unknown command\r\n")
+		}
+	}
+}
+
+// readCommand reads one RESP array of bulk strings
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if _, err := r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func TestSubscribeChanClosesOnCancel(t *testing.T) {
+	client := New(fakeSubscribeServer(t))
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(testCtx)
+	messages, err := client.SubscribeChan(ctx, "test:pubsub:cancel")
+	require.NoError(t, err)
+
+	// The reader is blocked waiting for a message that never comes
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case _, ok := <-messages:
+		assert.False(t, ok)
+	case <-time.After(2 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
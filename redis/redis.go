@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 var (
@@ -16,70 +18,95 @@ var (
 
 	// ErrConnectionFailed indicates a failed connection attempt
 	ErrConnectionFailed = errors.New("connection failed")
+
+	// ErrTimeout indicates a blocking operation returned nothing within its timeout
+	ErrTimeout = errors.New("timeout")
+
+	// ErrFieldTTLUnsupported indicates the server lacks hash field expiration (Redis < 7.4)
+	ErrFieldTTLUnsupported = errors.New("hash field expiration requires Redis 7.4 or later")
+
+	// ErrInvalidTTL indicates a TTL shorter than the 1ms Redis resolution
+	ErrInvalidTTL = errors.New("ttl must be at least 1ms")
 )
 
 // Client wraps the go-redis client with helper methods
 type Client struct {
 	*redis.Client
+
+	prefix       string
+	txMaxRetries int
+	loads        singleflight.Group
+}
+
+// options holds the go-redis options plus settings handled by the wrapper
+type options struct {
+	redis.Options
+	keyPrefix    string
+	txMaxRetries int
 }
 
 // Option configures the Redis client
-type Option func(*redis.Options)
+type Option func(*options)
 
 // New creates a new Redis client with default options
 func New(addr string, opts ...Option) *Client {
-	options := &redis.Options{
-		Addr:         addr,
-		Password:     "",
-		DB:           0,
-		DialTimeout:  5 * time.Second,
-		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 3 * time.Second,
-		PoolSize:     10,
-		MinIdleConns: 5,
-		MaxRetries:   3,
+	o := &options{
+		Options: redis.Options{
+			Addr:         addr,
+			Password:     "",
+			DB:           0,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			PoolSize:     10,
+			MinIdleConns: 5,
+			MaxRetries:   3,
+		},
+		txMaxRetries: defaultTxMaxRetries,
 	}
 
 	for _, opt := range opts {
-		opt(options)
+		opt(o)
 	}
 
 	return &Client{
-		Client: redis.NewClient(options),
+		Client:       redis.NewClient(&o.Options),
+		prefix:       o.keyPrefix,
+		txMaxRetries: o.txMaxRetries,
 	}
 }
 
 // WithPassword sets the Redis password
 func WithPassword(password string) Option {
-	return func(opts *redis.Options) {
+	return func(opts *options) {
 		opts.Password = password
 	}
 }
 
 // WithDB sets the Redis database number
 func WithDB(db int) Option {
-	return func(opts *redis.Options) {
+	return func(opts *options) {
 		opts.DB = db
 	}
 }
 
 // WithPoolSize sets the connection pool size
 func WithPoolSize(size int) Option {
-	return func(opts *redis.Options) {
+	return func(opts *options) {
 		opts.PoolSize = size
 	}
 }
 
 // WithMinIdleConns sets the minimum idle connections
 func WithMinIdleConns(conns int) Option {
-	return func(opts *redis.Options) {
+	return func(opts *options) {
 		opts.MinIdleConns = conns
 	}
 }
 
 // WithTimeout sets dial, read, and write timeouts
 func WithTimeout(timeout time.Duration) Option {
-	return func(opts *redis.Options) {
+	return func(opts *options) {
 		opts.DialTimeout = timeout
 		opts.ReadTimeout = timeout
 		opts.WriteTimeout = timeout
@@ -88,11 +115,105 @@ func WithTimeout(timeout time.Duration) Option {
 
 // WithMaxRetries sets the maximum number of retries
 func WithMaxRetries(retries int) Option {
-	return func(opts *redis.Options) {
+	return func(opts *options) {
 		opts.MaxRetries = retries
 	}
 }
 
+// WithKeyPrefix prepends prefix to every key passed to the client's helper
+// methods and strips it from keys returned by Keys and Scan
+func WithKeyPrefix(prefix string) Option {
+	return func(opts *options) {
+		opts.keyPrefix = prefix
+	}
+}
+
+// WithTxMaxRetries sets how many times Transaction retries after a watched
+// key was modified (default: 3)
+func WithTxMaxRetries(retries int) Option {
+	return func(opts *options) {
+		opts.txMaxRetries = retries
+	}
+}
+
+// key returns key with the client's prefix applied
+func (c *Client) key(key string) string {
+	return c.prefix + key
+}
+
+// prefixKeys returns keys with the client's prefix applied
+func (c *Client) prefixKeys(keys []string) []string {
+	if c.prefix == "" {
+		return keys
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	return prefixed
+}
+
+// prefixPairs applies the client's prefix to the keys of MSet-style
+// arguments: alternating key/value pairs or a single map
+func (c *Client) prefixPairs(pairs []interface{}) []interface{} {
+	if c.prefix == "" {
+		return pairs
+	}
+	if len(pairs) == 1 {
+		switch m := pairs[0].(type) {
+		case map[string]interface{}:
+			prefixed := make(map[string]interface{}, len(m))
+			for k, v := range m {
+				prefixed[c.prefix+k] = v
+			}
+			return []interface{}{prefixed}
+		case map[string]string:
+			prefixed := make(map[string]string, len(m))
+			for k, v := range m {
+				prefixed[c.prefix+k] = v
+			}
+			return []interface{}{prefixed}
+		}
+	}
+
+	prefixed := make([]interface{}, len(pairs))
+	copy(prefixed, pairs)
+	for i := 0; i < len(prefixed); i += 2 {
+		if key, ok := prefixed[i].(string); ok {
+			prefixed[i] = c.prefix + key
+		}
+	}
+	return prefixed
+}
+
+// pattern applies the client's prefix to a match pattern, treating an
+// empty pattern as matching every key
+func (c *Client) pattern(pattern string) string {
+	if c.prefix == "" {
+		return pattern
+	}
+	if pattern == "" {
+		pattern = "*"
+	}
+	return c.prefix + pattern
+}
+
+// trimKey removes the client's prefix from a key returned by Redis
+func (c *Client) trimKey(key string) string {
+	return strings.TrimPrefix(key, c.prefix)
+}
+
+// trimKeys removes the client's prefix from keys returned by Redis
+func (c *Client) trimKeys(keys []string) []string {
+	if c.prefix == "" {
+		return keys
+	}
+	for i, key := range keys {
+		keys[i] = c.trimKey(key)
+	}
+	return keys
+}
+
 // Ping checks the Redis connection
 func (c *Client) Ping(ctx context.Context) error {
 	_, err := c.Client.Ping(ctx).Result()
@@ -101,12 +222,12 @@ func (c *Client) Ping(ctx context.Context) error {
 
 // Set stores a key-value pair with expiration
 func (c *Client) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	return c.Client.Set(ctx, key, value, expiration).Err()
+	return c.Client.Set(ctx, c.key(key), value, expiration).Err()
 }
 
 // Get retrieves a value by key (returns ErrKeyNotFound if key doesn't exist)
 func (c *Client) Get(ctx context.Context, key string) (string, error) {
-	val, err := c.Client.Get(ctx, key).Result()
+	val, err := c.Client.Get(ctx, c.key(key)).Result()
 	if err == redis.Nil {
 		return "", ErrKeyNotFound
 	}
@@ -115,7 +236,7 @@ func (c *Client) Get(ctx context.Context, key string) (string, error) {
 
 // GetBytes retrieves a value as bytes by key
 func (c *Client) GetBytes(ctx context.Context, key string) ([]byte, error) {
-	val, err := c.Client.Get(ctx, key).Bytes()
+	val, err := c.Client.Get(ctx, c.key(key)).Bytes()
 	if err == redis.Nil {
 		return nil, ErrKeyNotFound
 	}
@@ -124,12 +245,12 @@ func (c *Client) GetBytes(ctx context.Context, key string) ([]byte, error) {
 
 // Delete removes one or more keys
 func (c *Client) Delete(ctx context.Context, keys ...string) error {
-	return c.Client.Del(ctx, keys...).Err()
+	return c.Client.Del(ctx, c.prefixKeys(keys)...).Err()
 }
 
 // Exists checks if one or more keys exist
 func (c *Client) Exists(ctx context.Context, keys ...string) (bool, error) {
-	count, err := c.Client.Exists(ctx, keys...).Result()
+	count, err := c.Client.Exists(ctx, c.prefixKeys(keys)...).Result()
 	return count > 0, err
 }
 
@@ -142,6 +263,24 @@ func (c *Client) SetJSON(ctx context.Context, key string, value interface{}, exp
 	return c.Set(ctx, key, jsonData, expiration)
 }
 
+// SetJSONNX stores a value as JSON only if the key doesn't exist, reporting whether it was written
+func (c *Client) SetJSONNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return c.SetNX(ctx, key, jsonData, expiration)
+}
+
+// SetJSONXX stores a value as JSON only if the key exists, reporting whether it was written
+func (c *Client) SetJSONXX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return c.SetXX(ctx, key, jsonData, expiration)
+}
+
 // GetJSON retrieves and unmarshals a JSON value into the provided type
 func (c *Client) GetJSON(ctx context.Context, key string, dest interface{}) error {
 	jsonData, err := c.GetBytes(ctx, key)
@@ -156,156 +295,399 @@ func (c *Client) GetJSON(ctx context.Context, key string, dest interface{}) erro
 	return nil
 }
 
+// GetJSONValue retrieves and unmarshals a JSON value of type T (returns ErrKeyNotFound if key doesn't exist)
+func GetJSONValue[T any](ctx context.Context, c *Client, key string) (T, error) {
+	var result T
+	if err := c.GetJSON(ctx, key, &result); err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
+
 // Increment increments the value of a key by the specified amount
 func (c *Client) Increment(ctx context.Context, key string, value int64) (int64, error) {
 	if value == 1 {
-		return c.Client.Incr(ctx, key).Result()
+		return c.Client.Incr(ctx, c.key(key)).Result()
 	}
-	return c.Client.IncrBy(ctx, key, value).Result()
+	return c.Client.IncrBy(ctx, c.key(key), value).Result()
 }
 
 // Decrement decrements the value of a key by the specified amount
 func (c *Client) Decrement(ctx context.Context, key string, value int64) (int64, error) {
 	if value == 1 {
-		return c.Client.Decr(ctx, key).Result()
+		return c.Client.Decr(ctx, c.key(key)).Result()
 	}
-	return c.Client.DecrBy(ctx, key, value).Result()
+	return c.Client.DecrBy(ctx, c.key(key), value).Result()
+}
+
+// IncrementFloat increments the value of a key by a floating point amount
+func (c *Client) IncrementFloat(ctx context.Context, key string, value float64) (float64, error) {
+	return c.Client.IncrByFloat(ctx, c.key(key), value).Result()
+}
+
+// incrementWithTTLScript increments a key and sets its expiration only when the key is new
+var incrementWithTTLScript = redis.NewScript(`
+local created = redis.call("EXISTS", KEYS[1]) == 0
+local value = redis.call("INCRBY", KEYS[1], ARGV[1])
+if created then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return value
+`)
+
+// IncrementWithTTL increments a key and sets ttl when the key is created,
+// without resetting it on later increments (useful for rate-limit windows).
+// It returns ErrInvalidTTL if ttl is under 1ms, which would expire the new
+// counter immediately.
+func (c *Client) IncrementWithTTL(ctx context.Context, key string, value int64, ttl time.Duration) (int64, error) {
+	if ttl.Milliseconds() <= 0 {
+		return 0, ErrInvalidTTL
+	}
+	return incrementWithTTLScript.Run(ctx, c.Client, []string{c.key(key)}, value, ttl.Milliseconds()).Int64()
 }
 
 // Expire sets a key's expiration time
 func (c *Client) Expire(ctx context.Context, key string, expiration time.Duration) error {
-	return c.Client.Expire(ctx, key, expiration).Err()
+	return c.Client.Expire(ctx, c.key(key), expiration).Err()
 }
 
 // TTL returns the remaining time to live of a key
 func (c *Client) TTL(ctx context.Context, key string) (time.Duration, error) {
-	return c.Client.TTL(ctx, key).Result()
+	return c.Client.TTL(ctx, c.key(key)).Result()
 }
 
 // SetNX sets a key only if it doesn't already exist (atomic operation)
 func (c *Client) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
-	return c.Client.SetNX(ctx, key, value, expiration).Result()
+	return c.Client.SetNX(ctx, c.key(key), value, expiration).Result()
 }
 
 // SetXX sets a key only if it already exists (atomic operation)
 func (c *Client) SetXX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
-	return c.Client.SetXX(ctx, key, value, expiration).Result()
+	return c.Client.SetXX(ctx, c.key(key), value, expiration).Result()
 }
 
 // MGet retrieves multiple values at once
 func (c *Client) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
-	return c.Client.MGet(ctx, keys...).Result()
+	return c.Client.MGet(ctx, c.prefixKeys(keys)...).Result()
 }
 
 // MSet sets multiple key-value pairs at once
 func (c *Client) MSet(ctx context.Context, pairs ...interface{}) error {
-	return c.Client.MSet(ctx, pairs...).Err()
+	return c.Client.MSet(ctx, c.prefixPairs(pairs)...).Err()
 }
 
 // Keys finds all keys matching a pattern
 func (c *Client) Keys(ctx context.Context, pattern string) ([]string, error) {
-	return c.Client.Keys(ctx, pattern).Result()
+	keys, err := c.Client.Keys(ctx, c.pattern(pattern)).Result()
+	return c.trimKeys(keys), err
 }
 
 // Scan iterates over keys matching a pattern (safer than Keys for large datasets)
 func (c *Client) Scan(ctx context.Context, cursor uint64, match string, count int64) ([]string, uint64, error) {
-	return c.Client.Scan(ctx, cursor, match, count).Result()
+	keys, next, err := c.Client.Scan(ctx, cursor, c.pattern(match), count).Result()
+	return c.trimKeys(keys), next, err
+}
+
+// ScanEach calls fn for every key matching a pattern, managing the SCAN cursor
+// internally. Iteration stops at the first error from fn or when ctx is done.
+func (c *Client) ScanEach(ctx context.Context, match string, count int64, fn func(key string) error) error {
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		keys, next, err := c.Client.Scan(ctx, cursor, c.pattern(match), count).Result()
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := fn(c.trimKey(key)); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// CountKeys counts keys matching a pattern using SCAN, without blocking the server
+func (c *Client) CountKeys(ctx context.Context, pattern string) (int64, error) {
+	var count int64
+	iter := c.Client.Scan(ctx, 0, c.pattern(pattern), 1000).Iterator()
+	for iter.Next(ctx) {
+		count++
+	}
+	return count, iter.Err()
+}
+
+// MemoryUsage returns the number of bytes a key uses (returns ErrKeyNotFound if key doesn't exist)
+func (c *Client) MemoryUsage(ctx context.Context, key string) (int64, error) {
+	val, err := c.Client.MemoryUsage(ctx, c.key(key)).Result()
+	if err == redis.Nil {
+		return 0, ErrKeyNotFound
+	}
+	return val, err
 }
 
 // HSet sets a field in a hash
 func (c *Client) HSet(ctx context.Context, key string, field string, value interface{}) error {
-	return c.Client.HSet(ctx, key, field, value).Err()
+	return c.Client.HSet(ctx, c.key(key), field, value).Err()
 }
 
 // HGet retrieves a field from a hash
 func (c *Client) HGet(ctx context.Context, key string, field string) (string, error) {
-	return c.Client.HGet(ctx, key, field).Result()
+	return c.Client.HGet(ctx, c.key(key), field).Result()
 }
 
 // HGetAll retrieves all fields from a hash
 func (c *Client) HGetAll(ctx context.Context, key string) (map[string]string, error) {
-	return c.Client.HGetAll(ctx, key).Result()
+	return c.Client.HGetAll(ctx, c.key(key)).Result()
 }
 
 // HDel deletes one or more fields from a hash
 func (c *Client) HDel(ctx context.Context, key string, fields ...string) error {
-	return c.Client.HDel(ctx, key, fields...).Err()
+	return c.Client.HDel(ctx, c.key(key), fields...).Err()
 }
 
 // HMSet sets multiple fields in a hash at once
 func (c *Client) HMSet(ctx context.Context, key string, pairs ...interface{}) error {
-	return c.Client.HMSet(ctx, key, pairs...).Err()
+	return c.Client.HMSet(ctx, c.key(key), pairs...).Err()
+}
+
+// HScan calls fn for every field matching a pattern in a hash without loading
+// the whole hash into memory. Iteration stops at the first error from fn or
+// when ctx is done.
+func (c *Client) HScan(ctx context.Context, key string, match string, count int64, fn func(field, value string) error) error {
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		pairs, next, err := c.Client.HScan(ctx, c.key(key), cursor, match, count).Result()
+		if err != nil {
+			return err
+		}
+		for i := 0; i+1 < len(pairs); i += 2 {
+			if err := fn(pairs[i], pairs[i+1]); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// HExpire sets an expiration on hash fields (returns ErrKeyNotFound if the hash or a field doesn't exist)
+func (c *Client) HExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) error {
+	results, err := c.Client.HPExpire(ctx, c.key(key), expiration, fields...).Result()
+	if err != nil {
+		return fieldTTLError(err)
+	}
+	for _, result := range results {
+		if result == -2 {
+			return ErrKeyNotFound
+		}
+	}
+	return nil
+}
+
+// HTTL gets the remaining time to live of a hash field, or -1 if it has no
+// expiration (returns ErrKeyNotFound if the hash or field doesn't exist)
+func (c *Client) HTTL(ctx context.Context, key string, field string) (time.Duration, error) {
+	results, err := c.Client.HPTTL(ctx, c.key(key), field).Result()
+	if err != nil {
+		return 0, fieldTTLError(err)
+	}
+	if len(results) == 0 || results[0] == -2 {
+		return 0, ErrKeyNotFound
+	}
+	if results[0] == -1 {
+		return -1, nil
+	}
+	return time.Duration(results[0]) * time.Millisecond, nil
+}
+
+// fieldTTLError maps unknown-command errors from older servers to ErrFieldTTLUnsupported
+func fieldTTLError(err error) error {
+	if strings.Contains(strings.ToLower(err.Error()), "unknown command") {
+		return fmt.Errorf("%w: %v", ErrFieldTTLUnsupported, err)
+	}
+	return err
 }
 
 // LPush prepends one or more values to a list
 func (c *Client) LPush(ctx context.Context, key string, values ...interface{}) error {
-	return c.Client.LPush(ctx, key, values...).Err()
+	return c.Client.LPush(ctx, c.key(key), values...).Err()
 }
 
 // RPush appends one or more values to a list
 func (c *Client) RPush(ctx context.Context, key string, values ...interface{}) error {
-	return c.Client.RPush(ctx, key, values...).Err()
+	return c.Client.RPush(ctx, c.key(key), values...).Err()
 }
 
 // LPop removes and returns the first element of a list
 func (c *Client) LPop(ctx context.Context, key string) (string, error) {
-	return c.Client.LPop(ctx, key).Result()
+	return c.Client.LPop(ctx, c.key(key)).Result()
 }
 
 // RPop removes and returns the last element of a list
 func (c *Client) RPop(ctx context.Context, key string) (string, error) {
-	return c.Client.RPop(ctx, key).Result()
+	return c.Client.RPop(ctx, c.key(key)).Result()
+}
+
+// blockingPollInterval bounds each blocking call so context cancellation is
+// noticed without abandoning a connection that may still receive an element
+const blockingPollInterval = time.Second
+
+// BLPop removes and returns the first element of the first non-empty list,
+// blocking up to timeout (0 blocks until ctx is done). It returns the list
+// key and the value, or ErrTimeout if nothing arrived in time.
+// Timeouts are rounded up to whole seconds.
+func (c *Client) BLPop(ctx context.Context, timeout time.Duration, keys ...string) (string, string, error) {
+	return c.blockingPop(ctx, c.Client.BLPop, timeout, keys)
+}
+
+// BRPop is like BLPop but pops from the tail of the list
+func (c *Client) BRPop(ctx context.Context, timeout time.Duration, keys ...string) (string, string, error) {
+	return c.blockingPop(ctx, c.Client.BRPop, timeout, keys)
+}
+
+// blockingPop runs pop in blockingPollInterval slices until an element
+// arrives, timeout elapses or ctx is done. Redis blocks in whole seconds, so
+// the timeout is effectively rounded up to the next second.
+func (c *Client) blockingPop(
+	ctx context.Context,
+	pop func(ctx context.Context, timeout time.Duration, keys ...string) *redis.StringSliceCmd,
+	timeout time.Duration,
+	keys []string,
+) (string, string, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	keys = c.prefixKeys(keys)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", "", err
+		}
+
+		result, err := pop(ctx, blockingPollInterval, keys...).Result()
+		if err == nil {
+			return c.trimKey(result[0]), result[1], nil
+		}
+		if err != redis.Nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return "", "", ctxErr
+			}
+			return "", "", err
+		}
+		if !deadline.IsZero() && !time.Now().Before(deadline) {
+			return "", "", ErrTimeout
+		}
+	}
 }
 
 // LLen returns the length of a list
 func (c *Client) LLen(ctx context.Context, key string) (int64, error) {
-	return c.Client.LLen(ctx, key).Result()
+	return c.Client.LLen(ctx, c.key(key)).Result()
 }
 
 // LRange returns elements from a list
 func (c *Client) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
-	return c.Client.LRange(ctx, key, start, stop).Result()
+	return c.Client.LRange(ctx, c.key(key), start, stop).Result()
 }
 
 // SAdd adds one or more members to a set
 func (c *Client) SAdd(ctx context.Context, key string, members ...interface{}) error {
-	return c.Client.SAdd(ctx, key, members...).Err()
+	return c.Client.SAdd(ctx, c.key(key), members...).Err()
 }
 
 // SMembers returns all members of a set
 func (c *Client) SMembers(ctx context.Context, key string) ([]string, error) {
-	return c.Client.SMembers(ctx, key).Result()
+	return c.Client.SMembers(ctx, c.key(key)).Result()
 }
 
 // SIsMember checks if a value is a member of a set
 func (c *Client) SIsMember(ctx context.Context, key string, member interface{}) (bool, error) {
-	return c.Client.SIsMember(ctx, key, member).Result()
+	return c.Client.SIsMember(ctx, c.key(key), member).Result()
 }
 
 // SRem removes one or more members from a set
 func (c *Client) SRem(ctx context.Context, key string, members ...interface{}) error {
-	return c.Client.SRem(ctx, key, members...).Err()
+	return c.Client.SRem(ctx, c.key(key), members...).Err()
 }
 
 // ZAdd adds one or more members with scores to a sorted set
 func (c *Client) ZAdd(ctx context.Context, key string, members ...redis.Z) error {
-	return c.Client.ZAdd(ctx, key, members...).Err()
+	return c.Client.ZAdd(ctx, c.key(key), members...).Err()
 }
 
 // ZRange returns elements from a sorted set by index range
 func (c *Client) ZRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
-	return c.Client.ZRange(ctx, key, start, stop).Result()
+	return c.Client.ZRange(ctx, c.key(key), start, stop).Result()
 }
 
 // ZRangeByScore returns elements from a sorted set by score range
 func (c *Client) ZRangeByScore(ctx context.Context, key string, min, max string) ([]string, error) {
 	opt := &redis.ZRangeBy{Min: min, Max: max}
-	return c.Client.ZRangeByScore(ctx, key, opt).Result()
+	return c.Client.ZRangeByScore(ctx, c.key(key), opt).Result()
 }
 
 // ZRem removes one or more members from a sorted set
 func (c *Client) ZRem(ctx context.Context, key string, members ...interface{}) error {
-	return c.Client.ZRem(ctx, key, members...).Err()
+	return c.Client.ZRem(ctx, c.key(key), members...).Err()
+}
+
+// ZAddScore adds a single member with a score to a sorted set
+func (c *Client) ZAddScore(ctx context.Context, key string, score float64, member string) error {
+	return c.Client.ZAdd(ctx, c.key(key), redis.Z{Score: score, Member: member}).Err()
+}
+
+// ZRevRange returns elements from a sorted set by index range, highest score first
+func (c *Client) ZRevRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	return c.Client.ZRevRange(ctx, c.key(key), start, stop).Result()
+}
+
+// ZRangeWithScores returns elements with their scores from a sorted set by index range
+func (c *Client) ZRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error) {
+	return c.Client.ZRangeWithScores(ctx, c.key(key), start, stop).Result()
+}
+
+// ZScore returns the score of a member (returns ErrKeyNotFound if the member doesn't exist)
+func (c *Client) ZScore(ctx context.Context, key string, member string) (float64, error) {
+	score, err := c.Client.ZScore(ctx, c.key(key), member).Result()
+	if err == redis.Nil {
+		return 0, ErrKeyNotFound
+	}
+	return score, err
+}
+
+// ZRank returns the 0-based rank of a member, lowest score first (returns ErrKeyNotFound if the member doesn't exist)
+func (c *Client) ZRank(ctx context.Context, key string, member string) (int64, error) {
+	rank, err := c.Client.ZRank(ctx, c.key(key), member).Result()
+	if err == redis.Nil {
+		return 0, ErrKeyNotFound
+	}
+	return rank, err
+}
+
+// ZRevRank returns the 0-based rank of a member, highest score first (returns ErrKeyNotFound if the member doesn't exist)
+func (c *Client) ZRevRank(ctx context.Context, key string, member string) (int64, error) {
+	rank, err := c.Client.ZRevRank(ctx, c.key(key), member).Result()
+	if err == redis.Nil {
+		return 0, ErrKeyNotFound
+	}
+	return rank, err
 }
 
 // Publish publishes a message to a channel
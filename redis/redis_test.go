@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -12,6 +14,23 @@ import (
 
 var testCtx = context.Background()
 
+// newTestClient returns a client connected to a local Redis, skipping the
+// test when Redis is unavailable or in short mode
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	client := New("localhost:6379")
+	t.Cleanup(func() { client.Close() })
+
+	if err := client.Ping(testCtx); err != nil {
+		t.Skip("Redis not available, skipping test")
+	}
+	return client
+}
+
 func TestNew(t *testing.T) {
 	client := New("localhost:6379")
 	assert.NotNil(t, client)
@@ -155,6 +174,79 @@ func TestSetJSONGetJSON(t *testing.T) {
 	client.Delete(testCtx, "test:user")
 }
 
+func TestGetJSONValue(t *testing.T) {
+	client := newTestClient(t)
+	defer client.Delete(testCtx, "test:user:value", "test:user:invalid")
+
+	type User struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	user := User{ID: 1, Name: "John"}
+	require.NoError(t, client.SetJSON(testCtx, "test:user:value", user, 10*time.Second))
+
+	result, err := GetJSONValue[User](testCtx, client, "test:user:value")
+	require.NoError(t, err)
+	assert.Equal(t, user, result)
+
+	ptr, err := GetJSONValue[*User](testCtx, client, "test:user:value")
+	require.NoError(t, err)
+	assert.Equal(t, &user, ptr)
+
+	_, err = GetJSONValue[User](testCtx, client, "test:user:missing")
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	require.NoError(t, client.Set(testCtx, "test:user:invalid", "not json", 10*time.Second))
+	result, err = GetJSONValue[User](testCtx, client, "test:user:invalid")
+	assert.Error(t, err)
+	assert.Equal(t, User{}, result)
+}
+
+func TestSetJSONNXXX(t *testing.T) {
+	client := newTestClient(t)
+	client.Delete(testCtx, "test:leader")
+	defer client.Delete(testCtx, "test:leader")
+
+	type Leader struct {
+		ID string `json:"id"`
+	}
+
+	// XX on a missing key doesn't write
+	ok, err := client.SetJSONXX(testCtx, "test:leader", Leader{ID: "a"}, 10*time.Second)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// First writer wins with NX
+	ok, err = client.SetJSONNX(testCtx, "test:leader", Leader{ID: "a"}, 10*time.Second)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = client.SetJSONNX(testCtx, "test:leader", Leader{ID: "b"}, 10*time.Second)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	var leader Leader
+	require.NoError(t, client.GetJSON(testCtx, "test:leader", &leader))
+	assert.Equal(t, "a", leader.ID)
+
+	// XX on an existing key writes
+	ok, err = client.SetJSONXX(testCtx, "test:leader", Leader{ID: "c"}, 10*time.Second)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, client.GetJSON(testCtx, "test:leader", &leader))
+	assert.Equal(t, "c", leader.ID)
+}
+
+func TestSetJSONNXMarshalError(t *testing.T) {
+	client := New("localhost:6379")
+	defer client.Close()
+
+	_, err := client.SetJSONNX(testCtx, "test:leader", make(chan int), time.Second)
+	assert.ErrorContains(t, err, "failed to marshal JSON")
+}
+
 func TestExists(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test")
@@ -379,6 +471,59 @@ func TestHashOperations(t *testing.T) {
 	client.Delete(testCtx, "test:hash")
 }
 
+func TestHScan(t *testing.T) {
+	client := newTestClient(t)
+	defer client.Delete(testCtx, "test:hscan")
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, client.HSet(testCtx, "test:hscan", fmt.Sprintf("field:%d", i), i))
+	}
+	require.NoError(t, client.HSet(testCtx, "test:hscan", "other", "x"))
+
+	fields := make(map[string]string)
+	err := client.HScan(testCtx, "test:hscan", "field:*", 5, func(field, value string) error {
+		fields[field] = value
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, fields, 20)
+	assert.Equal(t, "7", fields["field:7"])
+}
+
+func TestHashFieldTTL(t *testing.T) {
+	client := newTestClient(t)
+	defer client.Delete(testCtx, "test:hash:ttl")
+
+	require.NoError(t, client.HMSet(testCtx, "test:hash:ttl", "token", "abc", "name", "John"))
+
+	err := client.HExpire(testCtx, "test:hash:ttl", time.Minute, "token")
+	if errors.Is(err, ErrFieldTTLUnsupported) {
+		t.Skip("Redis server does not support hash field expiration")
+	}
+	require.NoError(t, err)
+
+	ttl, err := client.HTTL(testCtx, "test:hash:ttl", "token")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 50*time.Second)
+
+	ttl, err = client.HTTL(testCtx, "test:hash:ttl", "name")
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(-1), ttl)
+
+	_, err = client.HTTL(testCtx, "test:hash:ttl", "missing")
+	assert.Equal(t, ErrKeyNotFound, err)
+	assert.Equal(t, ErrKeyNotFound, client.HExpire(testCtx, "test:hash:ttl", time.Minute, "missing"))
+	assert.Equal(t, ErrKeyNotFound, client.HExpire(testCtx, "test:hash:none", time.Minute, "token"))
+}
+
+func TestFieldTTLError(t *testing.T) {
+	err := fieldTTLError(errors.New("ERR unknown command 'hpexpire', with args beginning with: "))
+	assert.ErrorIs(t, err, ErrFieldTTLUnsupported)
+
+	other := errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	assert.Equal(t, other, fieldTTLError(other))
+}
+
 func TestListOperations(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test")
@@ -427,6 +572,43 @@ func TestListOperations(t *testing.T) {
 	client.Delete(testCtx, "test:list")
 }
 
+func TestBlockingPop(t *testing.T) {
+	client := newTestClient(t)
+	defer client.Delete(testCtx, "test:queue:a", "test:queue:b")
+
+	require.NoError(t, client.RPush(testCtx, "test:queue:b", "first", "last"))
+
+	key, value, err := client.BLPop(testCtx, time.Second, "test:queue:a", "test:queue:b")
+	require.NoError(t, err)
+	assert.Equal(t, "test:queue:b", key)
+	assert.Equal(t, "first", value)
+
+	key, value, err = client.BRPop(testCtx, time.Second, "test:queue:b")
+	require.NoError(t, err)
+	assert.Equal(t, "test:queue:b", key)
+	assert.Equal(t, "last", value)
+
+	// Nothing left
+	_, _, err = client.BLPop(testCtx, time.Second, "test:queue:a", "test:queue:b")
+	assert.Equal(t, ErrTimeout, err)
+
+	// Element pushed while blocked
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		client.LPush(testCtx, "test:queue:a", "job")
+	}()
+	key, value, err = client.BRPop(testCtx, 0, "test:queue:a")
+	require.NoError(t, err)
+	assert.Equal(t, "test:queue:a", key)
+	assert.Equal(t, "job", value)
+
+	// Cancellation
+	ctx, cancel := context.WithTimeout(testCtx, 300*time.Millisecond)
+	defer cancel()
+	_, _, err = client.BLPop(ctx, 0, "test:queue:a")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestSetOperations(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test")
@@ -511,6 +693,163 @@ func TestSortedSetOperations(t *testing.T) {
 	client.Delete(testCtx, "test:zset")
 }
 
+func TestLeaderboard(t *testing.T) {
+	client := newTestClient(t)
+	defer client.Delete(testCtx, "test:leaderboard")
+
+	require.NoError(t, client.ZAddScore(testCtx, "test:leaderboard", 100, "alice"))
+	require.NoError(t, client.ZAddScore(testCtx, "test:leaderboard", 200, "bob"))
+	require.NoError(t, client.ZAddScore(testCtx, "test:leaderboard", 150, "charlie"))
+
+	top, err := client.ZRevRange(testCtx, "test:leaderboard", 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob", "charlie"}, top)
+
+	withScores, err := client.ZRangeWithScores(testCtx, "test:leaderboard", 0, -1)
+	require.NoError(t, err)
+	assert.Equal(t, []redis.Z{
+		{Score: 100, Member: "alice"},
+		{Score: 150, Member: "charlie"},
+		{Score: 200, Member: "bob"},
+	}, withScores)
+
+	score, err := client.ZScore(testCtx, "test:leaderboard", "charlie")
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, score)
+
+	rank, err := client.ZRank(testCtx, "test:leaderboard", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rank)
+
+	rank, err = client.ZRevRank(testCtx, "test:leaderboard", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rank)
+
+	_, err = client.ZScore(testCtx, "test:leaderboard", "dave")
+	assert.Equal(t, ErrKeyNotFound, err)
+	_, err = client.ZRank(testCtx, "test:leaderboard", "dave")
+	assert.Equal(t, ErrKeyNotFound, err)
+	_, err = client.ZRevRank(testCtx, "test:leaderboard", "dave")
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+func TestIncrementFloat(t *testing.T) {
+	client := newTestClient(t)
+	client.Delete(testCtx, "test:counter:float")
+	defer client.Delete(testCtx, "test:counter:float")
+
+	val, err := client.IncrementFloat(testCtx, "test:counter:float", 1.5)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, val)
+
+	val, err = client.IncrementFloat(testCtx, "test:counter:float", -0.25)
+	require.NoError(t, err)
+	assert.Equal(t, 1.25, val)
+}
+
+func TestIncrementWithTTL(t *testing.T) {
+	client := newTestClient(t)
+	client.Delete(testCtx, "test:counter:ttl")
+	defer client.Delete(testCtx, "test:counter:ttl")
+
+	val, err := client.IncrementWithTTL(testCtx, "test:counter:ttl", 1, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), val)
+
+	ttl, err := client.TTL(testCtx, "test:counter:ttl")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+	assert.LessOrEqual(t, ttl, 10*time.Second)
+
+	// Later increments don't reset the TTL
+	val, err = client.IncrementWithTTL(testCtx, "test:counter:ttl", 2, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), val)
+
+	ttl, err = client.TTL(testCtx, "test:counter:ttl")
+	require.NoError(t, err)
+	assert.LessOrEqual(t, ttl, 10*time.Second)
+}
+
+func TestIncrementWithTTLInvalidTTL(t *testing.T) {
+	// Rejected before contacting Redis, so no server is needed
+	client := New("localhost:0")
+	defer client.Close()
+
+	for _, ttl := range []time.Duration{0, -time.Second, time.Microsecond} {
+		_, err := client.IncrementWithTTL(testCtx, "test:counter:ttl", 1, ttl)
+		assert.ErrorIs(t, err, ErrInvalidTTL, "ttl %s", ttl)
+	}
+}
+
+func TestScanEach(t *testing.T) {
+	client := newTestClient(t)
+
+	for i := 0; i < 25; i++ {
+		require.NoError(t, client.Set(testCtx, fmt.Sprintf("test:scaneach:%d", i), i, time.Minute))
+	}
+	defer func() {
+		client.ScanEach(testCtx, "test:scaneach:*", 100, func(key string) error {
+			return client.Delete(testCtx, key)
+		})
+	}()
+
+	seen := make(map[string]bool)
+	err := client.ScanEach(testCtx, "test:scaneach:*", 5, func(key string) error {
+		seen[key] = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, seen, 25)
+
+	// Errors from fn stop iteration
+	stop := errors.New("stop")
+	calls := 0
+	err = client.ScanEach(testCtx, "test:scaneach:*", 5, func(key string) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+
+	// Cancelled context stops iteration
+	ctx, cancel := context.WithCancel(testCtx)
+	cancel()
+	err = client.ScanEach(ctx, "test:scaneach:*", 5, func(key string) error { return nil })
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestCountKeys(t *testing.T) {
+	client := newTestClient(t)
+
+	for _, key := range []string{"test:count:1", "test:count:2", "test:count:3"} {
+		require.NoError(t, client.Set(testCtx, key, "value", time.Minute))
+	}
+	defer client.Delete(testCtx, "test:count:1", "test:count:2", "test:count:3")
+
+	count, err := client.CountKeys(testCtx, "test:count:*")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = client.CountKeys(testCtx, "test:count:none:*")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestMemoryUsage(t *testing.T) {
+	client := newTestClient(t)
+
+	require.NoError(t, client.Set(testCtx, "test:memory", "some value", time.Minute))
+	defer client.Delete(testCtx, "test:memory")
+
+	usage, err := client.MemoryUsage(testCtx, "test:memory")
+	require.NoError(t, err)
+	assert.Greater(t, usage, int64(0))
+
+	_, err = client.MemoryUsage(testCtx, "test:memory:missing")
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
 func TestStats(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test")
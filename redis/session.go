@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultSessionPrefix = "session:"
+
+// SessionStore stores session data as Redis hashes keyed by a random ID
+type SessionStore struct {
+	client *Client
+	prefix string
+}
+
+// NewSessionStore creates a session store on client whose keys are
+// prefixed with "session:"
+func NewSessionStore(client *Client) *SessionStore {
+	return &SessionStore{client: client, prefix: defaultSessionPrefix}
+}
+
+// Create stores data under a new random session ID that expires after ttl.
+// data must not be empty since Redis does not store empty hashes.
+func (s *SessionStore) Create(ctx context.Context, data map[string]string, ttl time.Duration) (string, error) {
+	if len(data) == 0 {
+		return "", errors.New("session data must not be empty")
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate session id: %w", err)
+	}
+	id := hex.EncodeToString(buf)
+
+	pairs := make([]interface{}, 0, len(data)*2)
+	for k, v := range data {
+		pairs = append(pairs, k, v)
+	}
+
+	key := s.client.key(s.key(id))
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HMSet(ctx, key, pairs...)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Get returns the session data (returns ErrKeyNotFound if the session doesn't exist or has expired)
+func (s *SessionStore) Get(ctx context.Context, id string) (map[string]string, error) {
+	data, err := s.client.HGetAll(ctx, s.key(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrKeyNotFound
+	}
+	return data, nil
+}
+
+// Touch extends the session's expiration to ttl from now (returns ErrKeyNotFound if the session doesn't exist)
+func (s *SessionStore) Touch(ctx context.Context, id string, ttl time.Duration) error {
+	ok, err := s.client.Client.Expire(ctx, s.client.key(s.key(id)), ttl).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrKeyNotFound
+	}
+	return nil
+}
+
+// Destroy deletes the session
+func (s *SessionStore) Destroy(ctx context.Context, id string) error {
+	return s.client.Delete(ctx, s.key(id))
+}
+
+func (s *SessionStore) key(id string) string {
+	return s.prefix + id
+}
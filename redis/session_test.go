@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStoreLifecycle(t *testing.T) {
+	client := newTestClient(t)
+	store := NewSessionStore(client)
+
+	// Create
+	id, err := store.Create(testCtx, map[string]string{"user_id": "42", "role": "admin"}, 10*time.Second)
+	require.NoError(t, err)
+	assert.Len(t, id, 64)
+	defer store.Destroy(testCtx, id)
+
+	// Get
+	data, err := store.Get(testCtx, id)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user_id": "42", "role": "admin"}, data)
+
+	// Touch
+	require.NoError(t, store.Touch(testCtx, id, time.Minute))
+	ttl, err := client.TTL(testCtx, "session:"+id)
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 10*time.Second)
+
+	// Destroy
+	require.NoError(t, store.Destroy(testCtx, id))
+	_, err = store.Get(testCtx, id)
+	assert.Equal(t, ErrKeyNotFound, err)
+	assert.Equal(t, ErrKeyNotFound, store.Touch(testCtx, id, time.Minute))
+}
+
+func TestSessionStoreCreateEmpty(t *testing.T) {
+	store := NewSessionStore(New("localhost:6379"))
+	defer store.client.Close()
+
+	_, err := store.Create(testCtx, nil, time.Minute)
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultTxMaxRetries is how many times Transaction re-runs its closure after
+// a watched key changed
+const defaultTxMaxRetries = 3
+
+// ErrTxConflict indicates a transaction kept aborting because watched keys
+// were modified concurrently
+var ErrTxConflict = errors.New("transaction aborted: watched keys modified")
+
+// Tx is an optimistic transaction. Reads run immediately on the connection
+// holding the WATCH; writes are queued and applied atomically in MULTI/EXEC
+// after the closure returns.
+type Tx struct {
+	client *Client
+	tx     *redis.Tx
+	queued []func(pipe redis.Pipeliner)
+}
+
+// Transaction watches keys, runs fn and commits the writes it queued. If any
+// watched key is modified before the commit, the transaction is aborted and fn
+// is run again from scratch, up to WithTxMaxRetries times (default 3), before
+// returning ErrTxConflict. An error from fn aborts without retrying.
+func (c *Client) Transaction(ctx context.Context, keys []string, fn func(tx *Tx) error) error {
+	txf := func(rtx *redis.Tx) error {
+		tx := &Tx{client: c, tx: rtx}
+		if err := fn(tx); err != nil {
+			return err
+		}
+		if len(tx.queued) == 0 {
+			return nil
+		}
+
+		_, err := rtx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, queue := range tx.queued {
+				queue(pipe)
+			}
+			return nil
+		})
+		return err
+	}
+
+	for attempt := 0; attempt <= c.txMaxRetries; attempt++ {
+		err := c.Client.Watch(ctx, txf, c.prefixKeys(keys)...)
+		if err != redis.TxFailedErr {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("%w after %d attempts", ErrTxConflict, c.txMaxRetries+1)
+}
+
+// Get reads a value by key (returns ErrKeyNotFound if key doesn't exist)
+func (t *Tx) Get(ctx context.Context, key string) (string, error) {
+	val, err := t.tx.Get(ctx, t.client.key(key)).Result()
+	if err == redis.Nil {
+		return "", ErrKeyNotFound
+	}
+	return val, err
+}
+
+// GetJSON reads and unmarshals a JSON value into dest (returns ErrKeyNotFound if key doesn't exist)
+func (t *Tx) GetJSON(ctx context.Context, key string, dest interface{}) error {
+	data, err := t.tx.Get(ctx, t.client.key(key)).Bytes()
+	if err == redis.Nil {
+		return ErrKeyNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return nil
+}
+
+// Set queues a key-value pair with expiration
+func (t *Tx) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) {
+	key = t.client.key(key)
+	t.queued = append(t.queued, func(pipe redis.Pipeliner) {
+		pipe.Set(ctx, key, value, expiration)
+	})
+}
+
+// SetJSON queues a JSON-serialized value with expiration
+func (t *Tx) SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	t.Set(ctx, key, data, expiration)
+	return nil
+}
+
+// Delete queues deletion of one or more keys
+func (t *Tx) Delete(ctx context.Context, keys ...string) {
+	keys = t.client.prefixKeys(keys)
+	t.queued = append(t.queued, func(pipe redis.Pipeliner) {
+		pipe.Del(ctx, keys...)
+	})
+}
@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction(t *testing.T) {
+	client := newTestClient(t)
+	defer client.Delete(testCtx, "test:tx:from", "test:tx:to")
+
+	require.NoError(t, client.Set(testCtx, "test:tx:from", "100", time.Minute))
+	require.NoError(t, client.Set(testCtx, "test:tx:to", "0", time.Minute))
+
+	transfer := func(tx *Tx) error {
+		from, err := tx.Get(testCtx, "test:tx:from")
+		if err != nil {
+			return err
+		}
+		to, err := tx.Get(testCtx, "test:tx:to")
+		if err != nil {
+			return err
+		}
+		f, _ := strconv.Atoi(from)
+		n, _ := strconv.Atoi(to)
+		tx.Set(testCtx, "test:tx:from", f-30, time.Minute)
+		tx.Set(testCtx, "test:tx:to", n+30, time.Minute)
+		return nil
+	}
+
+	err := client.Transaction(testCtx, []string{"test:tx:from", "test:tx:to"}, transfer)
+	require.NoError(t, err)
+
+	from, _ := client.Get(testCtx, "test:tx:from")
+	to, _ := client.Get(testCtx, "test:tx:to")
+	assert.Equal(t, "70", from)
+	assert.Equal(t, "30", to)
+
+	// An error from fn aborts without applying queued writes
+	errAbort := errors.New("abort")
+	err = client.Transaction(testCtx, []string{"test:tx:from"}, func(tx *Tx) error {
+		tx.Delete(testCtx, "test:tx:from")
+		return errAbort
+	})
+	assert.Equal(t, errAbort, err)
+	exists, _ := client.Exists(testCtx, "test:tx:from")
+	assert.True(t, exists)
+}
+
+func TestTransactionConflict(t *testing.T) {
+	client := New("localhost:6379", WithTxMaxRetries(2))
+	defer client.Close()
+	if err := client.Ping(testCtx); err != nil {
+		t.Skip("Redis not available, skipping test")
+	}
+	defer client.Delete(testCtx, "test:tx:watched")
+
+	calls := 0
+	err := client.Transaction(testCtx, []string{"test:tx:watched"}, func(tx *Tx) error {
+		calls++
+		// Modify the watched key from another connection
+		require.NoError(t, client.Set(context.Background(), "test:tx:watched", calls, time.Minute))
+		tx.Set(testCtx, "test:tx:watched", "tx", time.Minute)
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrTxConflict)
+	assert.Equal(t, 3, calls)
+
+	val, _ := client.Get(testCtx, "test:tx:watched")
+	assert.Equal(t, "3", val)
+}
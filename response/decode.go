@@ -0,0 +1,74 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MaxBodySize is the largest request body DecodeJSON accepts, in bytes
+var MaxBodySize int64 = 1 << 20
+
+// DecodeJSON decodes the JSON request body into dst, rejecting unknown
+// fields, trailing data and bodies larger than MaxBodySize. On failure it
+// writes a BadRequest response and returns false, so handlers can do:
+//
+//	if !response.DecodeJSON(w, r, &req) {
+//		return
+//	}
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	if err := decodeJSON(w, r, dst); err != nil {
+		BadRequest(w, err)
+		return false
+	}
+	return true
+}
+
+// decodeJSON decodes the body of r into dst, returning an error message
+// suitable for the client
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBodySize)
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		var maxBytesErr *http.MaxBytesError
+
+		switch {
+		case errors.As(err, &syntaxErr):
+			return fmt.Errorf("request body contains malformed JSON (at position %d)", syntaxErr.Offset)
+		case errors.Is(err, io.ErrUnexpectedEOF):
+			return errors.New("request body contains malformed JSON")
+		case errors.As(err, &typeErr):
+			if typeErr.Field != "" {
+				return fmt.Errorf("request body contains an invalid value for field %q", typeErr.Field)
+			}
+			return fmt.Errorf("request body contains an invalid value (at position %d)", typeErr.Offset)
+		case strings.HasPrefix(err.Error(), "json: unknown field "):
+			field := strings.TrimPrefix(err.Error(), "json: unknown field ")
+			return fmt.Errorf("request body contains unknown field %s", field)
+		case errors.Is(err, io.EOF):
+			return errors.New("request body must not be empty")
+		case errors.As(err, &maxBytesErr):
+			return fmt.Errorf("request body must not be larger than %d bytes", maxBytesErr.Limit)
+		default:
+			return err
+		}
+	}
+
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return fmt.Errorf("request body must not be larger than %d bytes", maxBytesErr.Limit)
+		}
+		return errors.New("request body must contain a single JSON value")
+	}
+
+	return nil
+}
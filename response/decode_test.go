@@ -0,0 +1,99 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type decodeRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantOK    bool
+		wantError string
+	}{
+		{
+			name:   "valid body",
+			body:   `{"name":"John","email":"john@example.com","age":30}`,
+			wantOK: true,
+		},
+		{
+			name:      "malformed JSON",
+			body:      `{"name":"John",}`,
+			wantError: "malformed JSON",
+		},
+		{
+			name:      "truncated JSON",
+			body:      `{"name":"John"`,
+			wantError: "malformed JSON",
+		},
+		{
+			name:      "unknown field",
+			body:      `{"name":"John","admin":true}`,
+			wantError: `unknown field "admin"`,
+		},
+		{
+			name:      "wrong type",
+			body:      `{"age":"thirty"}`,
+			wantError: `invalid value for field "age"`,
+		},
+		{
+			name:      "empty body",
+			body:      ``,
+			wantError: "must not be empty",
+		},
+		{
+			name:      "trailing data",
+			body:      `{"name":"John"}{"name":"Jane"}`,
+			wantError: "single JSON value",
+		},
+		{
+			name:      "oversized body",
+			body:      `{"name":"` + strings.Repeat("a", int(MaxBodySize)) + `"}`,
+			wantError: "must not be larger than",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			var req decodeRequest
+			ok := DecodeJSON(w, r, &req)
+			if ok != tt.wantOK {
+				t.Fatalf("DecodeJSON() = %v, want %v", ok, tt.wantOK)
+			}
+
+			if ok {
+				if req.Name != "John" || req.Age != 30 {
+					t.Errorf("DecodeJSON() decoded %+v", req)
+				}
+				if w.Body.Len() != 0 {
+					t.Errorf("DecodeJSON() should not write a response on success, got: %v", w.Body.String())
+				}
+				return
+			}
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("DecodeJSON() statusCode = %v, want %v", w.Code, http.StatusBadRequest)
+			}
+			var resp Response
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("DecodeJSON() invalid JSON response: %v", err)
+			}
+			if !strings.Contains(resp.Error, tt.wantError) {
+				t.Errorf("DecodeJSON() error = %q, want to contain %q", resp.Error, tt.wantError)
+			}
+		})
+	}
+}
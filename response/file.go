@@ -0,0 +1,67 @@
+package response
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// Redirect redirects the request to url with 301 Moved Permanently if
+// permanent is true, or 302 Found otherwise
+func Redirect(w http.ResponseWriter, r *http.Request, url string, permanent bool) {
+	code := http.StatusFound
+	if permanent {
+		code = http.StatusMovedPermanently
+	}
+	http.Redirect(w, r, url, code)
+}
+
+// Attachment streams body as a file download named filename. Content-Length
+// is set when body is an io.Seeker; contentType defaults to
+// application/octet-stream.
+func Attachment(w http.ResponseWriter, filename, contentType string, body io.Reader) error {
+	size := int64(-1)
+	if s, ok := body.(io.Seeker); ok {
+		if n, err := remaining(s); err == nil {
+			size = n
+		}
+	}
+	return AttachmentSize(w, filename, contentType, body, size)
+}
+
+// AttachmentSize is like Attachment but sets Content-Length to size, for
+// readers that can't seek; a negative size leaves it unset
+func AttachmentSize(w http.ResponseWriter, filename, contentType string, body io.Reader, size int64) error {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	if size >= 0 {
+		h.Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	_, err := io.Copy(w, body)
+	return err
+}
+
+// remaining returns the number of bytes between the current offset of s and
+// its end, leaving the offset unchanged
+func remaining(s io.Seeker) (int64, error) {
+	cur, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	end, err := s.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.Seek(cur, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return end - cur, nil
+}
@@ -0,0 +1,121 @@
+package response
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedirect(t *testing.T) {
+	tests := []struct {
+		name      string
+		permanent bool
+		wantCode  int
+	}{
+		{name: "temporary", permanent: false, wantCode: http.StatusFound},
+		{name: "permanent", permanent: true, wantCode: http.StatusMovedPermanently},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/old", nil)
+			w := httptest.NewRecorder()
+
+			Redirect(w, r, "/new", tt.permanent)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Redirect() statusCode = %v, want %v", w.Code, tt.wantCode)
+			}
+			if w.Header().Get("Location") != "/new" {
+				t.Errorf("Redirect() Location = %v, want /new", w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestAttachment(t *testing.T) {
+	tests := []struct {
+		name            string
+		filename        string
+		contentType     string
+		body            func() io.Reader
+		wantType        string
+		wantDisposition string
+		wantLength      string
+	}{
+		{
+			name:            "seeker sets length",
+			filename:        "report.csv",
+			contentType:     "text/csv",
+			body:            func() io.Reader { return bytes.NewReader([]byte("a,b\n1,2\n")) },
+			wantType:        "text/csv",
+			wantDisposition: `attachment; filename=report.csv`,
+			wantLength:      "8",
+		},
+		{
+			name:        "partially read seeker",
+			filename:    "report.csv",
+			contentType: "text/csv",
+			body: func() io.Reader {
+				r := strings.NewReader("skip\na,b\n1,2\n")
+				r.Seek(5, io.SeekStart)
+				return r
+			},
+			wantType:        "text/csv",
+			wantDisposition: `attachment; filename=report.csv`,
+			wantLength:      "8",
+		},
+		{
+			name:            "plain reader has no length",
+			filename:        "my report.csv",
+			contentType:     "",
+			body:            func() io.Reader { return io.MultiReader(strings.NewReader("a,b\n1,2\n")) },
+			wantType:        "application/octet-stream",
+			wantDisposition: `attachment; filename="my report.csv"`,
+			wantLength:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := Attachment(w, tt.filename, tt.contentType, tt.body()); err != nil {
+				t.Fatalf("Attachment() error = %v", err)
+			}
+
+			if w.Code != http.StatusOK {
+				t.Errorf("Attachment() statusCode = %v, want %v", w.Code, http.StatusOK)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("Attachment() Content-Type = %v, want %v", got, tt.wantType)
+			}
+			if got := w.Header().Get("Content-Disposition"); got != tt.wantDisposition {
+				t.Errorf("Attachment() Content-Disposition = %v, want %v", got, tt.wantDisposition)
+			}
+			if got := w.Header().Get("Content-Length"); got != tt.wantLength {
+				t.Errorf("Attachment() Content-Length = %v, want %v", got, tt.wantLength)
+			}
+			if w.Body.String() != "a,b\n1,2\n" {
+				t.Errorf("Attachment() body = %q", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAttachmentSize(t *testing.T) {
+	w := httptest.NewRecorder()
+	body := io.MultiReader(strings.NewReader("hello"))
+	if err := AttachmentSize(w, "hello.txt", "text/plain", body, 5); err != nil {
+		t.Fatalf("AttachmentSize() error = %v", err)
+	}
+
+	if got := w.Header().Get("Content-Length"); got != "5" {
+		t.Errorf("AttachmentSize() Content-Length = %v, want 5", got)
+	}
+	if w.Body.String() != "hello" {
+		t.Errorf("AttachmentSize() body = %q, want %q", w.Body.String(), "hello")
+	}
+}
@@ -0,0 +1,94 @@
+package response
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Write writes data like JSON, but as XML if the request's Accept header
+// prefers application/xml (or text/xml) over application/json. Data must be
+// encodable as XML in that case; maps, for instance, are not.
+func Write(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) error {
+	return write(w, r, Response{
+		Code: statusCode,
+		Data: data,
+	})
+}
+
+// WriteError writes an error like Error, negotiating the format like Write
+func WriteError(w http.ResponseWriter, r *http.Request, statusCode int, err error) error {
+	var errMsg string
+	if err != nil {
+		errMsg = err.Error()
+	}
+
+	return write(w, r, Response{
+		Code:  statusCode,
+		Error: errMsg,
+	})
+}
+
+// write encodes resp in the format negotiated from r
+func write(w http.ResponseWriter, r *http.Request, resp Response) error {
+	w.Header().Add("Vary", "Accept")
+
+	if !wantsXML(r) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.Code)
+		return json.NewEncoder(w).Encode(resp)
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(resp.Code)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(resp)
+}
+
+// wantsXML reports whether the Accept header of r ranks an XML media type
+// above application/json. Wildcards count for neither, and ties go to the
+// type listed first.
+func wantsXML(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+
+	var jsonQ, xmlQ float64
+	jsonFirst := false
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case "application/json":
+			if q > jsonQ {
+				jsonQ = q
+				jsonFirst = xmlQ == 0
+			}
+		case "application/xml", "text/xml":
+			if q > xmlQ {
+				xmlQ = q
+			}
+		}
+	}
+
+	if xmlQ == 0 {
+		return false
+	}
+	return xmlQ > jsonQ || (xmlQ == jsonQ && !jsonFirst)
+}
@@ -0,0 +1,136 @@
+package response
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type xmlUser struct {
+	ID   int    `json:"id" xml:"id"`
+	Name string `json:"name" xml:"name"`
+}
+
+func TestWantsXML(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   bool
+	}{
+		{name: "no accept header", accept: "", want: false},
+		{name: "json", accept: "application/json", want: false},
+		{name: "xml", accept: "application/xml", want: true},
+		{name: "text xml", accept: "text/xml", want: true},
+		{name: "wildcard", accept: "*/*", want: false},
+		{name: "xml with wildcard", accept: "application/xml, */*;q=0.8", want: true},
+		{name: "json preferred by q", accept: "application/xml;q=0.5, application/json", want: false},
+		{name: "xml preferred by q", accept: "application/json;q=0.5, application/xml", want: true},
+		{name: "tie goes to first json", accept: "application/json, application/xml", want: false},
+		{name: "tie goes to first xml", accept: "application/xml, application/json", want: true},
+		{name: "xml refused", accept: "application/xml;q=0", want: false},
+		{name: "malformed", accept: "application/xml;q=abc", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			if got := wantsXML(r); got != tt.want {
+				t.Errorf("wantsXML() with Accept %q = %v, want %v", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteXML(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/xml")
+
+	w := httptest.NewRecorder()
+	err := Write(w, r, http.StatusOK, xmlUser{ID: 1, Name: "John"})
+	if err != nil {
+		t.Errorf("Write() error = %v", err)
+		return
+	}
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Write() statusCode = %v, want %v", w.Code, http.StatusOK)
+	}
+	if w.Header().Get("Content-Type") != "application/xml" {
+		t.Errorf("Write() Content-Type = %v, want application/xml", w.Header().Get("Content-Type"))
+	}
+	if w.Header().Get("Vary") != "Accept" {
+		t.Errorf("Write() Vary = %v, want Accept", w.Header().Get("Vary"))
+	}
+
+	body := w.Body.String()
+	want := xml.Header + `<response><code>200</code><data><id>1</id><name>John</name></data></response>`
+	if body != want {
+		t.Errorf("Write() body = %v, want %v", body, want)
+	}
+}
+
+func TestWriteJSONFallback(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/html, */*")
+
+	w := httptest.NewRecorder()
+	if err := Write(w, r, http.StatusOK, xmlUser{ID: 1, Name: "John"}); err != nil {
+		t.Errorf("Write() error = %v", err)
+		return
+	}
+
+	if w.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Write() Content-Type = %v, want application/json", w.Header().Get("Content-Type"))
+	}
+	body := strings.TrimSpace(w.Body.String())
+	want := `{"code":200,"data":{"id":1,"name":"John"}}`
+	if body != want {
+		t.Errorf("Write() body = %v, want %v", body, want)
+	}
+}
+
+func TestWriteErrorXML(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/xml")
+
+	w := httptest.NewRecorder()
+	if err := WriteError(w, r, http.StatusNotFound, errors.New("user not found")); err != nil {
+		t.Errorf("WriteError() error = %v", err)
+		return
+	}
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("WriteError() statusCode = %v, want %v", w.Code, http.StatusNotFound)
+	}
+
+	var resp Response
+	if err := xml.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Errorf("WriteError() invalid XML response: %v", err)
+		return
+	}
+	if resp.Code != http.StatusNotFound || resp.Error != "user not found" {
+		t.Errorf("WriteError() = %+v", resp)
+	}
+}
+
+func TestResponseXMLDetails(t *testing.T) {
+	data, err := xml.Marshal(Response{
+		Code:      http.StatusUnprocessableEntity,
+		ErrorCode: CodeValidationFailed,
+		Details:   []FieldError{{Field: "email", Message: "is required"}},
+	})
+	if err != nil {
+		t.Fatalf("xml.Marshal() error = %v", err)
+	}
+
+	want := `<response><code>422</code><error_code>VALIDATION_FAILED</error_code><detail><field>email</field><message>is required</message></detail></response>`
+	if string(data) != want {
+		t.Errorf("xml.Marshal() = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,59 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDKey is the context key for the request ID set by WithRequestID
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying id, for JSONCtx and ErrorCtx
+// to echo back to the client
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the ID set with WithRequestID, or else the
+// trace ID of the OpenTelemetry span in ctx, which is the trace_id the
+// logger package adds to log lines. It returns "" if there is neither.
+func RequestIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok && id != "" {
+		return id
+	}
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+		return spanCtx.TraceID().String()
+	}
+	return ""
+}
+
+// JSONCtx is like JSON but also sets RequestID from ctx
+func JSONCtx(ctx context.Context, w http.ResponseWriter, statusCode int, data interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	return json.NewEncoder(w).Encode(Response{
+		Code:      statusCode,
+		Data:      data,
+		RequestID: RequestIDFromContext(ctx),
+	})
+}
+
+// ErrorCtx is like Error but also sets RequestID from ctx
+func ErrorCtx(ctx context.Context, w http.ResponseWriter, statusCode int, err error) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	var errMsg string
+	if err != nil {
+		errMsg = err.Error()
+	}
+
+	return json.NewEncoder(w).Encode(Response{
+		Code:      statusCode,
+		Error:     errMsg,
+		RequestID: RequestIDFromContext(ctx),
+	})
+}
@@ -0,0 +1,82 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRequestIDFromContext(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "empty context", ctx: context.Background(), want: ""},
+		{name: "explicit request ID", ctx: WithRequestID(context.Background(), "req-123"), want: "req-123"},
+		{name: "trace ID fallback", ctx: spanCtx, want: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{name: "explicit ID wins over trace ID", ctx: WithRequestID(spanCtx, "req-123"), want: "req-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RequestIDFromContext(tt.ctx); got != tt.want {
+				t.Errorf("RequestIDFromContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONCtx(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-123")
+
+	w := httptest.NewRecorder()
+	if err := JSONCtx(ctx, w, http.StatusOK, map[string]string{"status": "ok"}); err != nil {
+		t.Errorf("JSONCtx() error = %v", err)
+		return
+	}
+
+	body := strings.TrimSpace(w.Body.String())
+	want := `{"code":200,"data":{"status":"ok"},"request_id":"req-123"}`
+	if body != want {
+		t.Errorf("JSONCtx() body = %v, want %v", body, want)
+	}
+
+	// Without an ID the field is omitted
+	w2 := httptest.NewRecorder()
+	JSONCtx(context.Background(), w2, http.StatusOK, "ok")
+	if strings.Contains(w2.Body.String(), `"request_id"`) {
+		t.Errorf("JSONCtx() body should NOT contain 'request_id' field without an ID, got: %v", w2.Body.String())
+	}
+}
+
+func TestErrorCtx(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-123")
+
+	w := httptest.NewRecorder()
+	if err := ErrorCtx(ctx, w, http.StatusInternalServerError, errors.New("database error")); err != nil {
+		t.Errorf("ErrorCtx() error = %v", err)
+		return
+	}
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("ErrorCtx() statusCode = %v, want %v", w.Code, http.StatusInternalServerError)
+	}
+	body := strings.TrimSpace(w.Body.String())
+	want := `{"code":500,"error":"database error","request_id":"req-123"}`
+	if body != want {
+		t.Errorf("ErrorCtx() body = %v, want %v", body, want)
+	}
+}
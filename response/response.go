@@ -2,13 +2,31 @@ package response
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"net/http"
+	"strconv"
+
+	"github.com/davidsugianto/go-pkgs/pagination"
 )
 
+// CodeValidationFailed is the ErrorCode ValidationError responds with
+const CodeValidationFailed = "VALIDATION_FAILED"
+
 type Response struct {
-	Code  int         `json:"code"`
-	Data  interface{} `json:"data,omitempty"`
-	Error string      `json:"error,omitempty"`
+	XMLName   xml.Name     `json:"-" xml:"response"`
+	Code      int          `json:"code" xml:"code"`
+	Data      interface{}  `json:"data,omitempty" xml:"data,omitempty"`
+	Meta      interface{}  `json:"meta,omitempty" xml:"meta,omitempty"`
+	Error     string       `json:"error,omitempty" xml:"error,omitempty"`
+	ErrorCode string       `json:"error_code,omitempty" xml:"error_code,omitempty"`
+	Details   []FieldError `json:"details,omitempty" xml:"detail,omitempty"`
+	RequestID string       `json:"request_id,omitempty" xml:"request_id,omitempty"`
+}
+
+// FieldError describes why a single request field is invalid
+type FieldError struct {
+	Field   string `json:"field" xml:"field"`
+	Message string `json:"message" xml:"message"`
 }
 
 func JSON(w http.ResponseWriter, statusCode int, data interface{}) error {
@@ -24,6 +42,18 @@ func Success(w http.ResponseWriter, data interface{}) error {
 	return JSON(w, http.StatusOK, data)
 }
 
+// SuccessWithMeta writes a 200 OK response with data and meta, e.g.
+// map[string]interface{}{"pagination": p} for list endpoints
+func SuccessWithMeta(w http.ResponseWriter, data, meta interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(Response{
+		Code: http.StatusOK,
+		Data: data,
+		Meta: meta,
+	})
+}
+
 func Created(w http.ResponseWriter, data interface{}) error {
 	return JSON(w, http.StatusCreated, data)
 }
@@ -33,6 +63,12 @@ func NoContent(w http.ResponseWriter) {
 }
 
 func Error(w http.ResponseWriter, statusCode int, err error) error {
+	return ErrorWithCode(w, statusCode, "", err)
+}
+
+// ErrorWithCode is like Error but also sets a machine-readable ErrorCode
+// (e.g. "USER_NOT_FOUND") clients can branch on
+func ErrorWithCode(w http.ResponseWriter, statusCode int, errorCode string, err error) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
@@ -42,8 +78,22 @@ func Error(w http.ResponseWriter, statusCode int, err error) error {
 	}
 
 	return json.NewEncoder(w).Encode(Response{
-		Code:  statusCode,
-		Error: errMsg,
+		Code:      statusCode,
+		Error:     errMsg,
+		ErrorCode: errorCode,
+	})
+}
+
+// ValidationError writes a 422 Unprocessable Entity response with
+// ErrorCode CodeValidationFailed and the field-level details
+func ValidationError(w http.ResponseWriter, details []FieldError) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	return json.NewEncoder(w).Encode(Response{
+		Code:      http.StatusUnprocessableEntity,
+		Error:     "validation failed",
+		ErrorCode: CodeValidationFailed,
+		Details:   details,
 	})
 }
 
@@ -75,3 +125,13 @@ func StatusCode(w http.ResponseWriter, statusCode int, message string) error {
 		Error: message,
 	})
 }
+
+// ListHeaders sets the X-Total-Count, X-Page, X-Page-Size and X-Total-Pages
+// headers from p. Call it before writing the body.
+func ListHeaders(w http.ResponseWriter, p pagination.Pagination) {
+	h := w.Header()
+	h.Set("X-Total-Count", strconv.Itoa(p.TotalData))
+	h.Set("X-Page", strconv.Itoa(p.Page))
+	h.Set("X-Page-Size", strconv.Itoa(p.PageSize))
+	h.Set("X-Total-Pages", strconv.Itoa(p.TotalPage))
+}
@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/davidsugianto/go-pkgs/pagination"
 )
 
 func TestJSON(t *testing.T) {
@@ -126,6 +128,35 @@ func TestSuccess(t *testing.T) {
 	}
 }
 
+func TestSuccessWithMeta(t *testing.T) {
+	p := pagination.Pagination{Page: 2, PageSize: 10}
+	p.SetTotal(45)
+
+	w := httptest.NewRecorder()
+	err := SuccessWithMeta(w, []string{"a", "b"}, map[string]interface{}{"pagination": p})
+	if err != nil {
+		t.Errorf("SuccessWithMeta() error = %v", err)
+		return
+	}
+
+	if w.Code != http.StatusOK {
+		t.Errorf("SuccessWithMeta() statusCode = %v, want %v", w.Code, http.StatusOK)
+	}
+
+	body := strings.TrimSpace(w.Body.String())
+	want := `{"code":200,"data":["a","b"],"meta":{"pagination":{"page":2,"page_size":10,"total_data":45,"total_page":5}}}`
+	if body != want {
+		t.Errorf("SuccessWithMeta() body = %v, want %v", body, want)
+	}
+
+	// Success keeps omitting meta
+	w2 := httptest.NewRecorder()
+	Success(w2, "ok")
+	if strings.Contains(w2.Body.String(), `"meta"`) {
+		t.Errorf("Success() body should NOT contain 'meta' field, got: %v", w2.Body.String())
+	}
+}
+
 func TestCreated(t *testing.T) {
 	w := httptest.NewRecorder()
 	data := map[string]int{"id": 123}
@@ -229,6 +260,61 @@ func TestError(t *testing.T) {
 	}
 }
 
+func TestErrorWithCode(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := ErrorWithCode(w, http.StatusNotFound, "USER_NOT_FOUND", errors.New("user not found"))
+	if err != nil {
+		t.Errorf("ErrorWithCode() error = %v", err)
+		return
+	}
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("ErrorWithCode() statusCode = %v, want %v", w.Code, http.StatusNotFound)
+	}
+
+	body := strings.TrimSpace(w.Body.String())
+	want := `{"code":404,"error":"user not found","error_code":"USER_NOT_FOUND"}`
+	if body != want {
+		t.Errorf("ErrorWithCode() body = %v, want %v", body, want)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := ValidationError(w, []FieldError{
+		{Field: "email", Message: "must be a valid email address"},
+		{Field: "age", Message: "must be at least 18"},
+	})
+	if err != nil {
+		t.Errorf("ValidationError() error = %v", err)
+		return
+	}
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("ValidationError() statusCode = %v, want %v", w.Code, http.StatusUnprocessableEntity)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Errorf("ValidationError() invalid JSON response: %v", err)
+		return
+	}
+
+	if resp.ErrorCode != CodeValidationFailed {
+		t.Errorf("ValidationError() error_code = %v, want %v", resp.ErrorCode, CodeValidationFailed)
+	}
+	if len(resp.Details) != 2 || resp.Details[0].Field != "email" || resp.Details[1].Message != "must be at least 18" {
+		t.Errorf("ValidationError() details = %+v", resp.Details)
+	}
+
+	// Plain error helpers omit the new fields
+	w2 := httptest.NewRecorder()
+	BadRequest(w2, errors.New("invalid input"))
+	if body := w2.Body.String(); strings.Contains(body, `"error_code"`) || strings.Contains(body, `"details"`) {
+		t.Errorf("BadRequest() body should NOT contain error_code or details, got: %v", body)
+	}
+}
+
 func TestBadRequest(t *testing.T) {
 	w := httptest.NewRecorder()
 	err := BadRequest(w, errors.New("invalid input"))
@@ -476,3 +562,28 @@ func TestIntegration(t *testing.T) {
 		t.Errorf("Integration: Expected status 204, got %v", w3.Code)
 	}
 }
+
+func TestListHeaders(t *testing.T) {
+	p := pagination.Pagination{Page: 2, PageSize: 10}
+	p.SetTotal(45)
+
+	w := httptest.NewRecorder()
+	ListHeaders(w, p)
+	Success(w, []string{"a", "b"})
+
+	headers := map[string]string{
+		"X-Total-Count": "45",
+		"X-Page":        "2",
+		"X-Page-Size":   "10",
+		"X-Total-Pages": "5",
+	}
+	for name, want := range headers {
+		if got := w.Header().Get(name); got != want {
+			t.Errorf("ListHeaders() %s = %v, want %v", name, got, want)
+		}
+	}
+
+	if w.Code != http.StatusOK {
+		t.Errorf("ListHeaders() statusCode = %v, want %v", w.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,85 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Envelope describes the shape of the bodies a Writer produces. Empty field
+// names fall back to the standard "code", "data" and "error".
+type Envelope struct {
+	// CodeField names the status code field
+	CodeField string
+
+	// DataField names the payload field, e.g. "result"
+	DataField string
+
+	// ErrorField names the error message field
+	ErrorField string
+
+	// Unwrapped writes success payloads as the bare body, without an
+	// envelope; errors are written as {"<ErrorField>": message}
+	Unwrapped bool
+}
+
+// Writer writes responses with a configurable envelope. The zero Envelope
+// gives the same {code, data, error} bodies as the package functions.
+type Writer struct {
+	envelope Envelope
+}
+
+// NewWriter returns a Writer producing bodies shaped by e
+func NewWriter(e Envelope) *Writer {
+	if e.CodeField == "" {
+		e.CodeField = "code"
+	}
+	if e.DataField == "" {
+		e.DataField = "data"
+	}
+	if e.ErrorField == "" {
+		e.ErrorField = "error"
+	}
+	return &Writer{envelope: e}
+}
+
+// JSON writes data with the given status code
+func (wr *Writer) JSON(w http.ResponseWriter, statusCode int, data interface{}) error {
+	if wr.envelope.Unwrapped {
+		return writeBody(w, statusCode, data)
+	}
+
+	body := map[string]interface{}{wr.envelope.CodeField: statusCode}
+	if data != nil {
+		body[wr.envelope.DataField] = data
+	}
+	return writeBody(w, statusCode, body)
+}
+
+// Success writes data with status 200 OK
+func (wr *Writer) Success(w http.ResponseWriter, data interface{}) error {
+	return wr.JSON(w, http.StatusOK, data)
+}
+
+// Created writes data with status 201 Created
+func (wr *Writer) Created(w http.ResponseWriter, data interface{}) error {
+	return wr.JSON(w, http.StatusCreated, data)
+}
+
+// Error writes the error message with the given status code
+func (wr *Writer) Error(w http.ResponseWriter, statusCode int, err error) error {
+	body := map[string]interface{}{}
+	if !wr.envelope.Unwrapped {
+		body[wr.envelope.CodeField] = statusCode
+	}
+	if err != nil {
+		body[wr.envelope.ErrorField] = err.Error()
+	}
+	return writeBody(w, statusCode, body)
+}
+
+// writeBody writes body as JSON with the given status code
+func writeBody(w http.ResponseWriter, statusCode int, body interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	return json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,96 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name      string
+		envelope  Envelope
+		wantData  string
+		wantNil   string
+		wantError string
+	}{
+		{
+			name:      "default envelope",
+			envelope:  Envelope{},
+			wantData:  `{"code":200,"data":{"id":1}}`,
+			wantNil:   `{"code":200}`,
+			wantError: `{"code":404,"error":"user not found"}`,
+		},
+		{
+			name:      "custom field names",
+			envelope:  Envelope{DataField: "result", ErrorField: "message", CodeField: "status"},
+			wantData:  `{"result":{"id":1},"status":200}`,
+			wantNil:   `{"status":200}`,
+			wantError: `{"message":"user not found","status":404}`,
+		},
+		{
+			name:      "unwrapped",
+			envelope:  Envelope{Unwrapped: true},
+			wantData:  `{"id":1}`,
+			wantNil:   `null`,
+			wantError: `{"error":"user not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wr := NewWriter(tt.envelope)
+
+			w := httptest.NewRecorder()
+			if err := wr.Success(w, map[string]int{"id": 1}); err != nil {
+				t.Fatalf("Success() error = %v", err)
+			}
+			if w.Code != http.StatusOK {
+				t.Errorf("Success() statusCode = %v, want %v", w.Code, http.StatusOK)
+			}
+			if w.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("Success() Content-Type = %v, want application/json", w.Header().Get("Content-Type"))
+			}
+			if body := strings.TrimSpace(w.Body.String()); body != tt.wantData {
+				t.Errorf("Success() body = %v, want %v", body, tt.wantData)
+			}
+
+			w = httptest.NewRecorder()
+			wr.Success(w, nil)
+			if body := strings.TrimSpace(w.Body.String()); body != tt.wantNil {
+				t.Errorf("Success(nil) body = %v, want %v", body, tt.wantNil)
+			}
+
+			w = httptest.NewRecorder()
+			if err := wr.Error(w, http.StatusNotFound, errors.New("user not found")); err != nil {
+				t.Fatalf("Error() error = %v", err)
+			}
+			if w.Code != http.StatusNotFound {
+				t.Errorf("Error() statusCode = %v, want %v", w.Code, http.StatusNotFound)
+			}
+			if body := strings.TrimSpace(w.Body.String()); body != tt.wantError {
+				t.Errorf("Error() body = %v, want %v", body, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestWriterMatchesDefaults(t *testing.T) {
+	wr := NewWriter(Envelope{})
+
+	w1, w2 := httptest.NewRecorder(), httptest.NewRecorder()
+	Created(w1, []string{"a"})
+	wr.Created(w2, []string{"a"})
+	if w1.Code != w2.Code || w1.Body.String() != w2.Body.String() {
+		t.Errorf("Writer.Created() = %d %v, want %d %v", w2.Code, w2.Body.String(), w1.Code, w1.Body.String())
+	}
+
+	w1, w2 = httptest.NewRecorder(), httptest.NewRecorder()
+	Error(w1, http.StatusBadRequest, nil)
+	wr.Error(w2, http.StatusBadRequest, nil)
+	if w1.Body.String() != w2.Body.String() {
+		t.Errorf("Writer.Error(nil) = %v, want %v", w2.Body.String(), w1.Body.String())
+	}
+}